@@ -0,0 +1,348 @@
+// Command glicko2 runs a single rating period over a players file and a matches file, writing the updated players.
+//
+// Usage:
+//
+//	glicko2 -players players.csv -matches matches.csv [flags]
+//
+// Either file may be `-` to read from stdin, and output is written to stdout unless -out is given.
+// Players are read as `id,rating,deviation,volatility` and matches as `player1,player2,result[,timestamp]`,
+// either as CSV or JSON Lines (chosen by file extension or the -players-format and -matches-format flags).
+// By default, CSV players have a header and use the Glicko scale, the same as the output of `-format csv`,
+// so the output of one period can be used as the players file of the next.
+//
+// Exits with status 2 if the flags are invalid, and 1 if the period cannot be run.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Too-Zestyy/glicko2go"
+	glicko2io "github.com/Too-Zestyy/glicko2go/io"
+)
+
+const (
+	FORMAT_CSV   = "csv"
+	FORMAT_JSONL = "jsonl"
+	FORMAT_JSON  = "json"
+	FORMAT_TABLE = "table"
+
+	SCALE_GLICKO  = "glicko"
+	SCALE_GLICKO2 = "glicko2"
+)
+
+const (
+	// EXIT_FAILURE is the exit code when the period cannot be run, such as when a file cannot be read or written.
+	EXIT_FAILURE = 1
+	// EXIT_USAGE is the exit code when the flags are invalid.
+	EXIT_USAGE = 2
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "glicko2: %v\n", err)
+		}
+		os.Exit(exitCode(err))
+	}
+}
+
+// usageError is returned by run when the flags are invalid.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// exitCode returns the exit code for an error returned by run. Asking for help is not a failure.
+func exitCode(err error) int {
+	var usageErr *usageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &usageErr):
+		return EXIT_USAGE
+	default:
+		return EXIT_FAILURE
+	}
+}
+
+type options struct {
+	playersPath   string
+	playersFormat string
+	playersHeader bool
+	matchesPath   string
+	matchesFormat string
+	matchesHeader bool
+	inputScale    string
+
+	outputPath   string
+	outputFormat string
+	outputScale  string
+
+	settings glicko2go.Glicko2AlgorithmSettings
+}
+
+// validate checks every format and scale name, so that a typo fails before any input is read or output file is created.
+func (opts options) validate() error {
+	if _, err := inputFormat(opts.playersPath, opts.playersFormat); err != nil {
+		return fmt.Errorf("-players-format: %w", err)
+	}
+	if _, err := inputFormat(opts.matchesPath, opts.matchesFormat); err != nil {
+		return fmt.Errorf("-matches-format: %w", err)
+	}
+	if _, err := scaleFromName(opts.inputScale); err != nil {
+		return fmt.Errorf("-input-scale: %w", err)
+	}
+	if _, err := scaleFromName(opts.outputScale); err != nil {
+		return fmt.Errorf("-scale: %w", err)
+	}
+	switch opts.outputFormat {
+	case FORMAT_CSV, FORMAT_JSON, FORMAT_TABLE:
+	default:
+		return fmt.Errorf("-format: unknown output format %q", opts.outputFormat)
+	}
+	return opts.settings.Validate()
+}
+
+func parseFlags(args []string, stderr io.Writer) (options, error) {
+	var opts options
+
+	flags := flag.NewFlagSet("glicko2", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	flags.StringVar(&opts.playersPath, "players", "", "players file, or - for stdin (required)")
+	flags.StringVar(&opts.playersFormat, "players-format", "", "players file format: csv or jsonl (default: from file extension, or csv)")
+	flags.BoolVar(&opts.playersHeader, "players-header", true, "skip the first line of a CSV players file, as written by -format csv")
+	flags.StringVar(&opts.matchesPath, "matches", "", "matches file, or - for stdin (required)")
+	flags.StringVar(&opts.matchesFormat, "matches-format", "", "matches file format: csv or jsonl (default: from file extension, or csv)")
+	flags.BoolVar(&opts.matchesHeader, "matches-header", false, "skip the first line of a CSV matches file")
+	flags.StringVar(&opts.inputScale, "input-scale", SCALE_GLICKO, "scale of the players file: glicko or glicko2")
+
+	flags.StringVar(&opts.outputPath, "out", "-", "output file, or - for stdout")
+	flags.StringVar(&opts.outputFormat, "format", FORMAT_TABLE, "output format: csv, json or table")
+	flags.StringVar(&opts.outputScale, "scale", SCALE_GLICKO, "scale of the output: glicko or glicko2")
+
+	flags.Float64Var(&opts.settings.SystemConstant, "system-constant", glicko2go.GLICKO2_DEFAULT_SYSTEM_CONSTANT, "system constant (τ)")
+	flags.Float64Var(&opts.settings.ConvergenceTolerance, "tolerance", glicko2go.GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE, "convergence tolerance of the volatility solver")
+	flags.IntVar(&opts.settings.MaxIterations, "max-iterations", glicko2go.GLICKO2_DEFAULT_MAX_ITERATIONS, "maximum iterations of the volatility solver")
+
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+
+	if opts.playersPath == "" || opts.matchesPath == "" {
+		return opts, errors.New("both -players and -matches are required")
+	}
+	if opts.playersPath == "-" && opts.matchesPath == "-" {
+		return opts, errors.New("only one of -players and -matches can be read from stdin")
+	}
+	if err := opts.validate(); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	opts, err := parseFlags(args, stderr)
+	if err != nil {
+		return &usageError{err: err}
+	}
+
+	players, err := readPlayers(opts, stdin)
+	if err != nil {
+		return fmt.Errorf("reading players: %w", err)
+	}
+	matches, err := readMatches(opts, stdin)
+	if err != nil {
+		return fmt.Errorf("reading matches: %w", err)
+	}
+
+	updatedPlayers, err := glicko2go.PeriodCalculatorWithSettings(opts.settings)(players, matches)
+	if err != nil {
+		return err
+	}
+	outputScale, err := scaleFromName(opts.outputScale)
+	if err != nil {
+		return err
+	}
+
+	if opts.outputPath == "-" {
+		return writePlayers(stdout, opts.outputFormat, outputScale, updatedPlayers)
+	}
+
+	file, err := os.Create(opts.outputPath)
+	if err != nil {
+		return err
+	}
+	if err := writePlayers(file, opts.outputFormat, outputScale, updatedPlayers); err != nil {
+		file.Close()
+		return err
+	}
+	// Closing can report a failed write, which would otherwise leave a truncated file behind a successful exit
+	return file.Close()
+}
+
+//// Input
+
+// openInput opens `path`, or returns `stdin` if `path` is `-`.
+func openInput(path string, stdin io.Reader) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(stdin), nil
+	}
+	return os.Open(path)
+}
+
+// inputFormat returns `format` if it was given, otherwise the format matching the extension of `path`.
+func inputFormat(path string, format string) (string, error) {
+	if format == "" {
+		if ext := filepath.Ext(path); ext == ".jsonl" || ext == ".ndjson" {
+			return FORMAT_JSONL, nil
+		}
+		return FORMAT_CSV, nil
+	}
+	if format != FORMAT_CSV && format != FORMAT_JSONL {
+		return "", fmt.Errorf("unknown input format %q", format)
+	}
+	return format, nil
+}
+
+// scaleFromName returns the scale named `name`, where nil is the Glicko 2 scale.
+func scaleFromName(name string) (*glicko2go.Scale, error) {
+	switch name {
+	case SCALE_GLICKO:
+		scale := glicko2go.NewStandardScale()
+		return &scale, nil
+	case SCALE_GLICKO2:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown scale %q", name)
+	}
+}
+
+func readPlayers(opts options, stdin io.Reader) (map[int]glicko2go.Glicko2Player, error) {
+	format, err := inputFormat(opts.playersPath, opts.playersFormat)
+	if err != nil {
+		return nil, err
+	}
+	scale, err := scaleFromName(opts.inputScale)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := openInput(opts.playersPath, stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+
+	playerOptions := glicko2io.DefaultPlayerOptions()
+	playerOptions.HasHeader = opts.playersHeader
+	playerOptions.Scale = scale
+
+	if format == FORMAT_JSONL {
+		return glicko2io.ReadPlayersJSONL(input, playerOptions)
+	}
+	return glicko2io.ReadPlayersCSV(input, playerOptions)
+}
+
+func readMatches(opts options, stdin io.Reader) ([]glicko2go.Glicko2MatchByID, error) {
+	format, err := inputFormat(opts.matchesPath, opts.matchesFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := openInput(opts.matchesPath, stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+
+	if format == FORMAT_JSONL {
+		return glicko2io.ReadAllMatches(glicko2io.NewJSONLMatchReader(input))
+	}
+
+	matchOptions := glicko2io.DefaultCSVMatchOptions()
+	matchOptions.HasHeader = opts.matchesHeader
+	return glicko2io.ReadAllMatches(glicko2io.NewCSVMatchReader(input, matchOptions))
+}
+
+//// Output
+
+type outputPlayer struct {
+	ID               int     `json:"id"`
+	Rating           float64 `json:"rating"`
+	RatingDeviation  float64 `json:"rating_deviation"`
+	RatingVolatility float64 `json:"rating_volatility"`
+}
+
+// outputPlayers returns `players` sorted by ID, converted to `scale` if it is not nil.
+func outputPlayers(players map[int]glicko2go.Glicko2Player, scale *glicko2go.Scale) []outputPlayer {
+	ids := make([]int, 0, len(players))
+	for id := range players {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	output := make([]outputPlayer, len(ids))
+	for i, id := range ids {
+		player := players[id]
+		if scale != nil {
+			player.GlickoPlayer = scale.ConvertFromGlicko2(player)
+		}
+		output[i] = outputPlayer{ID: id, Rating: player.Rating, RatingDeviation: player.RatingDeviation, RatingVolatility: player.RatingVolatility}
+	}
+	return output
+}
+
+func writePlayers(w io.Writer, format string, scale *glicko2go.Scale, players map[int]glicko2go.Glicko2Player) error {
+	output := outputPlayers(players, scale)
+
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+
+	switch format {
+	case FORMAT_CSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write([]string{"id", "rating", "deviation", "volatility"}); err != nil {
+			return err
+		}
+		for _, player := range output {
+			if err := writer.Write([]string{strconv.Itoa(player.ID), formatFloat(player.Rating), formatFloat(player.RatingDeviation), formatFloat(player.RatingVolatility)}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case FORMAT_JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	case FORMAT_TABLE:
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(writer, "ID\tRating\tDeviation\tVolatility\t")
+		for _, player := range output {
+			fmt.Fprintf(writer, "%v\t%.2f\t%.2f\t%.6f\t\n", player.ID, player.Rating, player.RatingDeviation, player.RatingVolatility)
+		}
+		return writer.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
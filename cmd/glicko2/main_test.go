@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestRunExample runs the example at https://www.glicko.net/glicko/glicko2.pdf, reading matches from stdin.
+func TestRunExample(t *testing.T) {
+	playersPath := filepath.Join(t.TempDir(), "players.csv")
+	players := "id,rating,deviation\n" +
+		"1,1500,200\n" +
+		"2,1400,30\n" +
+		"3,1550,100\n" +
+		"4,1700,300\n"
+	if err := os.WriteFile(playersPath, []byte(players), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	matches := `{"player1_id":1,"player2_id":2,"result":1}` + "\n" +
+		`{"player1_id":1,"player2_id":3,"result":0}` + "\n" +
+		`{"player1_id":1,"player2_id":4,"result":0}` + "\n"
+
+	var stdout, stderr bytes.Buffer
+	err := run([]string{
+		"-players", playersPath, "-players-header", "-input-scale", "glicko",
+		"-matches", "-", "-matches-format", "jsonl",
+		"-format", "csv", "-scale", "glicko",
+	}, strings.NewReader(matches), &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("%v\n%v", err, stderr.String())
+	}
+
+	records, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 5 || records[1][0] != "1" {
+		t.Fatalf("Unexpected output: %v", records)
+	}
+
+	rating, _ := strconv.ParseFloat(records[1][1], 64)
+	deviation, _ := strconv.ParseFloat(records[1][2], 64)
+	if math.Abs(rating-1464.06) > 0.01 || math.Abs(deviation-151.52) > 0.01 {
+		t.Errorf("Player 1 does not match the paper: rating %v, deviation %v", rating, deviation)
+	}
+}
+
+func TestRunRejectsInvalidSettings(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"-players", "players.csv", "-matches", "matches.csv", "-tolerance", "0"}, strings.NewReader(""), &stdout, &stderr)
+	if err == nil {
+		t.Errorf("A tolerance of 0 did not cause an error")
+	}
+}
+
+func TestRunInvalidFlagsLeaveOutputIntact(t *testing.T) {
+	dir := t.TempDir()
+	playersPath := filepath.Join(dir, "players.csv")
+	if err := os.WriteFile(playersPath, []byte("id,rating,deviation\n1,1500,350\n2,1500,350\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outputPath := filepath.Join(dir, "results.txt")
+	if err := os.WriteFile(outputPath, []byte("previous results\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, flags := range [][]string{{"-format", "bogus"}, {"-scale", "bogus"}, {"-input-scale", "bogus"}, {"-matches-format", "bogus"}} {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"-players", playersPath, "-matches", "-", "-out", outputPath}, flags...)
+		if err := run(args, strings.NewReader("1,2,1\n"), &stdout, &stderr); err == nil {
+			t.Errorf("Flags %v did not cause an error", flags)
+		}
+
+		output, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(output) != "previous results\n" {
+			t.Errorf("Flags %v changed the output file to %q", flags, output)
+		}
+	}
+}
+
+// TestRunOutputRoundTrips feeds the CSV output of one run back into the next, using the default flags on both sides.
+func TestRunOutputRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	playersPath := filepath.Join(dir, "players.csv")
+	players := "id,rating,deviation,volatility\n" +
+		"1,1500,200,0.06\n" +
+		"2,1400,30,0.06\n" +
+		"3,1550,100,0.06\n"
+	if err := os.WriteFile(playersPath, []byte(players), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	matchesPath := filepath.Join(dir, "matches.csv")
+	if err := os.WriteFile(matchesPath, []byte("1,2,1\n1,3,0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyMatchesPath := filepath.Join(dir, "empty.csv")
+	if err := os.WriteFile(emptyMatchesPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var firstOutput, stderr bytes.Buffer
+	if err := run([]string{"-players", playersPath, "-matches", matchesPath, "-format", "csv"}, strings.NewReader(""), &firstOutput, &stderr); err != nil {
+		t.Fatalf("%v\n%v", err, stderr.String())
+	}
+	firstRecords, err := csv.NewReader(bytes.NewReader(firstOutput.Bytes())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var secondOutput bytes.Buffer
+	if err := run([]string{"-players", "-", "-matches", emptyMatchesPath, "-format", "csv"}, &firstOutput, &secondOutput, &stderr); err != nil {
+		t.Fatalf("%v\n%v", err, stderr.String())
+	}
+	secondRecords, err := csv.NewReader(&secondOutput).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(firstRecords) != 4 || len(secondRecords) != len(firstRecords) {
+		t.Fatalf("Unexpected output: \nFirst:  %v\nSecond: %v", firstRecords, secondRecords)
+	}
+	for i := 1; i < len(firstRecords); i++ {
+		first, second := firstRecords[i], secondRecords[i]
+		firstRating, _ := strconv.ParseFloat(first[1], 64)
+		secondRating, _ := strconv.ParseFloat(second[1], 64)
+		firstDeviation, _ := strconv.ParseFloat(first[2], 64)
+		secondDeviation, _ := strconv.ParseFloat(second[2], 64)
+
+		// Without any matches, only deviation changes between the two runs.
+		if first[0] != second[0] || math.Abs(firstRating-secondRating) > 1e-9 || first[3] != second[3] ||
+			secondDeviation <= firstDeviation || secondDeviation > firstDeviation+5 {
+			t.Errorf("Player changes unexpectedly after a round trip: \nFirst:  %v\nSecond: %v", first, second)
+		}
+	}
+}
+
+func TestRunExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	playersPath := filepath.Join(dir, "players.csv")
+	if err := os.WriteFile(playersPath, []byte("id,rating,deviation\n1,1500,350\n2,1500,350\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, test := range map[string]struct {
+		args     []string
+		exitCode int
+	}{
+		"success":         {[]string{"-players", playersPath, "-matches", "-"}, 0},
+		"help":            {[]string{"-h"}, 0},
+		"unknown flag":    {[]string{"-bogus"}, EXIT_USAGE},
+		"invalid format":  {[]string{"-players", playersPath, "-matches", "-", "-format", "bogus"}, EXIT_USAGE},
+		"missing players": {[]string{"-players", filepath.Join(dir, "missing.csv"), "-matches", "-"}, EXIT_FAILURE},
+		"invalid output":  {[]string{"-players", playersPath, "-matches", "-", "-out", filepath.Join(dir, "missing", "out.csv")}, EXIT_FAILURE},
+	} {
+		var stdout, stderr bytes.Buffer
+		err := run(test.args, strings.NewReader("1,2,1\n"), &stdout, &stderr)
+		if code := exitCode(err); code != test.exitCode {
+			t.Errorf("%v: expected exit code %v, got %v (%v)", name, test.exitCode, code, err)
+		}
+	}
+}
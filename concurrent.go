@@ -0,0 +1,118 @@
+package glicko2go
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// UpdatePeriodByIDConcurrently is equivalent to UpdatePeriodByID, but updates players across `workers` goroutines.
+// If `workers` is less than 1, runtime.GOMAXPROCS(0) workers are used.
+//
+// Every update only reads pre-period state, so the output is identical to UpdatePeriodByID regardless of scheduling.
+// If `ctx` is cancelled before every player has been updated, ctx.Err() is returned. If several players fail to update,
+// only one of their errors is returned.
+func UpdatePeriodByIDConcurrently[ID comparable](ctx context.Context, r *Rater, players map[ID]Glicko2Player, matches []Glicko2Match[ID],
+	policy MatchValidationPolicy, workers int) (map[ID]Glicko2Player, []MatchIssue[ID], error) {
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	newMatchLists, issues, err := buildPeriodMatchLists(players, matches, policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type playerUpdate struct {
+		id     ID
+		player Glicko2Player
+	}
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pending := make(chan playerUpdate, workers)
+	completed := make(chan playerUpdate, workers)
+
+	var firstErr error
+	var errOnce sync.Once
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for update := range pending {
+				updatedPlayer, err := r.UpdatePlayer(update.player, newMatchLists[update.id])
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				select {
+				case completed <- playerUpdate{id: update.id, player: updatedPlayer}:
+				case <-workerCtx.Done():
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pending)
+		for id, player := range players {
+			select {
+			case pending <- playerUpdate{id: id, player: player}:
+			case <-workerCtx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(completed)
+	}()
+
+	updatedPlayers := make(map[ID]Glicko2Player, len(players))
+	for update := range completed {
+		updatedPlayers[update.id] = update.player
+	}
+
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return updatedPlayers, issues, nil
+}
+
+// UpdatePeriodConcurrently is the `int` ID form of UpdatePeriodByIDConcurrently, using MATCH_VALIDATION_STRICT.
+func (r *Rater) UpdatePeriodConcurrently(ctx context.Context, players map[int]Glicko2Player, matches []Glicko2MatchByID, workers int) (map[int]Glicko2Player, error) {
+	updatedPlayers, _, err := UpdatePeriodByIDConcurrently(ctx, r, players, matches, MATCH_VALIDATION_STRICT, workers)
+	return updatedPlayers, err
+}
+
+// GenericConcurrentPeriodCalculatorWithSettings returns a function equivalent to GenericPeriodCalculatorWithSettings,
+// that updates players across `workers` goroutines and stops early if its context is cancelled.
+// See UpdatePeriodByIDConcurrently for details.
+func GenericConcurrentPeriodCalculatorWithSettings[ID comparable](settings Glicko2AlgorithmSettings, workers int) func(ctx context.Context,
+	players map[ID]Glicko2Player, matches []Glicko2Match[ID]) (map[ID]Glicko2Player, error) {
+
+	rater := NewRater(settings)
+
+	return func(ctx context.Context, players map[ID]Glicko2Player, matches []Glicko2Match[ID]) (map[ID]Glicko2Player, error) {
+		updatedPlayers, _, err := UpdatePeriodByIDConcurrently(ctx, rater, players, matches, MATCH_VALIDATION_STRICT, workers)
+		return updatedPlayers, err
+	}
+}
+
+// ConcurrentPeriodCalculatorWithSettings is GenericConcurrentPeriodCalculatorWithSettings for `int` player IDs.
+func ConcurrentPeriodCalculatorWithSettings(settings Glicko2AlgorithmSettings, workers int) func(ctx context.Context,
+	players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error) {
+	return GenericConcurrentPeriodCalculatorWithSettings[int](settings, workers)
+}
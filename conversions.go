@@ -1,38 +1,31 @@
 package glicko2go
 
+// The following functions use the standard Glicko scale. For other scales, see Scale.
+
 func GlickoRatingToGlicko2(gRating float64) float64 {
-	return (gRating - 1500) / 173.7178
+	return standardScale.RatingToGlicko2(gRating)
 }
 
 func Glicko2RatingToGlicko(g2Rating float64) float64 {
-	return g2Rating*173.7178 + 1500
+	return standardScale.Glicko2RatingToScale(g2Rating)
 }
 
 func GlickoDeviationToGlicko2(gDeviation float64) float64 {
-	return gDeviation / 173.7178
+	return standardScale.DeviationToGlicko2(gDeviation)
 }
 
 func Glicko2DeviationToGlicko(g2Deviation float64) float64 {
-	return g2Deviation * 173.7178
+	return standardScale.Glicko2DeviationToScale(g2Deviation)
 }
 
 func ConvertToGlicko2(gp GlickoPlayer, volatility float64) Glicko2Player {
-	return Glicko2Player{
-		GlickoPlayer: GlickoPlayer{
-			Rating:          GlickoRatingToGlicko2(gp.Rating),
-			RatingDeviation: GlickoDeviationToGlicko2(gp.RatingDeviation),
-		},
-		RatingVolatility: volatility,
-	}
+	return standardScale.ConvertToGlicko2(gp, volatility)
 }
 
 func ConvertToGlicko2WithDefaultVolatility(gp GlickoPlayer) Glicko2Player {
-	return ConvertToGlicko2(gp, GLICKO2_DEFAULT_PLAYER_VOLATILITY)
+	return standardScale.ConvertToGlicko2WithDefaultVolatility(gp)
 }
 
 func ConvertToGlicko(g2p Glicko2Player) GlickoPlayer {
-	return GlickoPlayer{
-		Rating:          Glicko2RatingToGlicko(g2p.Rating),
-		RatingDeviation: Glicko2DeviationToGlicko(g2p.RatingDeviation),
-	}
+	return standardScale.ConvertFromGlicko2(g2p)
 }
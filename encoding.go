@@ -0,0 +1,566 @@
+package glicko2go
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Players are encoded with stable field names, flattening Glicko2Player's embedded GlickoPlayer:
+//
+//	{"rating": 0, "rating_deviation": 2.014761872416068, "rating_volatility": 0.06}
+//
+// Text encoding uses the same values separated by spaces, and binary encoding stores the IEEE 754 bits of each value
+// in little-endian order. Every encoding preserves the exact bits of each value.
+//
+// Matches and settings support the same encodings, so they can be cached alongside players.
+
+var (
+	_ json.Marshaler             = Glicko2Player{}
+	_ json.Unmarshaler           = (*Glicko2Player)(nil)
+	_ encoding.TextMarshaler     = Glicko2Player{}
+	_ encoding.TextUnmarshaler   = (*Glicko2Player)(nil)
+	_ encoding.BinaryMarshaler   = Glicko2Player{}
+	_ encoding.BinaryUnmarshaler = (*Glicko2Player)(nil)
+
+	_ json.Marshaler             = GlickoPlayer{}
+	_ json.Unmarshaler           = (*GlickoPlayer)(nil)
+	_ encoding.TextMarshaler     = GlickoPlayer{}
+	_ encoding.TextUnmarshaler   = (*GlickoPlayer)(nil)
+	_ encoding.BinaryMarshaler   = GlickoPlayer{}
+	_ encoding.BinaryUnmarshaler = (*GlickoPlayer)(nil)
+
+	_ encoding.TextMarshaler     = Glicko2Match[int]{}
+	_ encoding.TextUnmarshaler   = (*Glicko2Match[int])(nil)
+	_ encoding.BinaryMarshaler   = Glicko2Match[int]{}
+	_ encoding.BinaryUnmarshaler = (*Glicko2Match[int])(nil)
+
+	_ encoding.TextMarshaler     = Glicko2AlgorithmSettings{}
+	_ encoding.TextUnmarshaler   = (*Glicko2AlgorithmSettings)(nil)
+	_ encoding.BinaryMarshaler   = Glicko2AlgorithmSettings{}
+	_ encoding.BinaryUnmarshaler = (*Glicko2AlgorithmSettings)(nil)
+
+	_ encoding.TextMarshaler     = GlickoAlgorithmSettings{}
+	_ encoding.TextUnmarshaler   = (*GlickoAlgorithmSettings)(nil)
+	_ encoding.BinaryMarshaler   = GlickoAlgorithmSettings{}
+	_ encoding.BinaryUnmarshaler = (*GlickoAlgorithmSettings)(nil)
+)
+
+type glickoPlayerJSON struct {
+	Rating          float64 `json:"rating"`
+	RatingDeviation float64 `json:"rating_deviation"`
+}
+
+type glicko2PlayerJSON struct {
+	Rating           float64 `json:"rating"`
+	RatingDeviation  float64 `json:"rating_deviation"`
+	RatingVolatility float64 `json:"rating_volatility"`
+}
+
+// glicko2MatchJSON, glicko2SettingsJSON and glickoSettingsJSON share their struct tags with the types they convert from,
+// but drop MarshalText so encoding/json writes them as objects.
+type glicko2MatchJSON[ID comparable] Glicko2Match[ID]
+type glicko2SettingsJSON Glicko2AlgorithmSettings
+type glickoSettingsJSON GlickoAlgorithmSettings
+
+//// JSON
+
+func (p GlickoPlayer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(glickoPlayerJSON{Rating: p.Rating, RatingDeviation: p.RatingDeviation})
+}
+
+func (p *GlickoPlayer) UnmarshalJSON(data []byte) error {
+	var decoded glickoPlayerJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = GlickoPlayer{Rating: decoded.Rating, RatingDeviation: decoded.RatingDeviation}
+	return nil
+}
+
+// MarshalJSON encodes the player as `{"rating": ..., "rating_deviation": ..., "rating_volatility": ...}`,
+// flattening the embedded GlickoPlayer.
+func (p Glicko2Player) MarshalJSON() ([]byte, error) {
+	return json.Marshal(glicko2PlayerJSON{Rating: p.Rating, RatingDeviation: p.RatingDeviation, RatingVolatility: p.RatingVolatility})
+}
+
+func (p *Glicko2Player) UnmarshalJSON(data []byte) error {
+	var decoded glicko2PlayerJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = Glicko2Player{
+		GlickoPlayer: GlickoPlayer{
+			Rating:          decoded.Rating,
+			RatingDeviation: decoded.RatingDeviation,
+		},
+		RatingVolatility: decoded.RatingVolatility,
+	}
+	return nil
+}
+
+func (m Glicko2Match[ID]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(glicko2MatchJSON[ID](m))
+}
+
+func (m *Glicko2Match[ID]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*glicko2MatchJSON[ID])(m))
+}
+
+func (s Glicko2AlgorithmSettings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(glicko2SettingsJSON(s))
+}
+
+func (s *Glicko2AlgorithmSettings) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*glicko2SettingsJSON)(s))
+}
+
+func (s GlickoAlgorithmSettings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(glickoSettingsJSON(s))
+}
+
+func (s *GlickoAlgorithmSettings) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*glickoSettingsJSON)(s))
+}
+
+// MarshalPlayerJSON encodes `p` with its rating and deviation converted to this scale, using the same field names as Glicko2Player.
+// Use NewStandardScale to output players on the Glicko scale.
+func (s Scale) MarshalPlayerJSON(p Glicko2Player) ([]byte, error) {
+	return json.Marshal(glicko2PlayerJSON{
+		Rating:           s.Glicko2RatingToScale(p.Rating),
+		RatingDeviation:  s.Glicko2DeviationToScale(p.RatingDeviation),
+		RatingVolatility: p.RatingVolatility,
+	})
+}
+
+// UnmarshalPlayerJSON decodes a player encoded by MarshalPlayerJSON, converting it back to the Glicko 2 scale.
+func (s Scale) UnmarshalPlayerJSON(data []byte) (Glicko2Player, error) {
+	var decoded glicko2PlayerJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return Glicko2Player{}, err
+	}
+	return s.ConvertToGlicko2(GlickoPlayer{Rating: decoded.Rating, RatingDeviation: decoded.RatingDeviation}, decoded.RatingVolatility), nil
+}
+
+//// Text
+
+// formatFloats formats `values` separated by spaces, using the fewest digits that exactly represent each value.
+func formatFloats(values ...float64) []byte {
+	var text []byte
+	for i, value := range values {
+		if i > 0 {
+			text = append(text, ' ')
+		}
+		text = strconv.AppendFloat(text, value, 'g', -1, 64)
+	}
+	return text
+}
+
+// parseFloats parses `count` space separated values formatted by formatFloats.
+func parseFloats(text []byte, count int) ([]float64, error) {
+	fields := strings.Fields(string(text))
+	if len(fields) != count {
+		return nil, fmt.Errorf("expected %v space separated values, got %v", count, len(fields))
+	}
+
+	values := make([]float64, count)
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (p GlickoPlayer) MarshalText() ([]byte, error) {
+	return formatFloats(p.Rating, p.RatingDeviation), nil
+}
+
+func (p *GlickoPlayer) UnmarshalText(text []byte) error {
+	values, err := parseFloats(text, 2)
+	if err != nil {
+		return fmt.Errorf("cannot decode GlickoPlayer: %w", err)
+	}
+	*p = GlickoPlayer{Rating: values[0], RatingDeviation: values[1]}
+	return nil
+}
+
+func (p Glicko2Player) MarshalText() ([]byte, error) {
+	return formatFloats(p.Rating, p.RatingDeviation, p.RatingVolatility), nil
+}
+
+func (p *Glicko2Player) UnmarshalText(text []byte) error {
+	values, err := parseFloats(text, 3)
+	if err != nil {
+		return fmt.Errorf("cannot decode Glicko2Player: %w", err)
+	}
+	*p = Glicko2Player{
+		GlickoPlayer: GlickoPlayer{
+			Rating:          values[0],
+			RatingDeviation: values[1],
+		},
+		RatingVolatility: values[2],
+	}
+	return nil
+}
+
+//// Binary
+
+// appendFloatBits appends the little-endian IEEE 754 bits of each value to `data`.
+func appendFloatBits(data []byte, values ...float64) []byte {
+	for _, value := range values {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(value))
+	}
+	return data
+}
+
+// readFloatBits reads `count` values written by appendFloatBits.
+func readFloatBits(data []byte, count int) ([]float64, error) {
+	if len(data) != count*8 {
+		return nil, fmt.Errorf("expected %v bytes, got %v", count*8, len(data))
+	}
+
+	values := make([]float64, count)
+	for i := range values {
+		values[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return values, nil
+}
+
+func (p GlickoPlayer) MarshalBinary() ([]byte, error) {
+	return appendFloatBits(make([]byte, 0, 16), p.Rating, p.RatingDeviation), nil
+}
+
+func (p *GlickoPlayer) UnmarshalBinary(data []byte) error {
+	values, err := readFloatBits(data, 2)
+	if err != nil {
+		return fmt.Errorf("cannot decode GlickoPlayer: %w", err)
+	}
+	*p = GlickoPlayer{Rating: values[0], RatingDeviation: values[1]}
+	return nil
+}
+
+func (p Glicko2Player) MarshalBinary() ([]byte, error) {
+	return appendFloatBits(make([]byte, 0, 24), p.Rating, p.RatingDeviation, p.RatingVolatility), nil
+}
+
+func (p *Glicko2Player) UnmarshalBinary(data []byte) error {
+	values, err := readFloatBits(data, 3)
+	if err != nil {
+		return fmt.Errorf("cannot decode Glicko2Player: %w", err)
+	}
+	*p = Glicko2Player{
+		GlickoPlayer: GlickoPlayer{
+			Rating:          values[0],
+			RatingDeviation: values[1],
+		},
+		RatingVolatility: values[2],
+	}
+	return nil
+}
+
+//// Matches
+//
+// Match IDs must be `int` or `string`, which are the ID types used by the period calculators and readers.
+// Text and binary encoding of a match with any other ID type returns an error.
+
+// encodeIDText returns `id` as a text field. Integers are written as-is, while strings are quoted so they may contain spaces.
+func encodeIDText[ID comparable](id ID) (string, error) {
+	switch id := any(id).(type) {
+	case int:
+		return strconv.Itoa(id), nil
+	case string:
+		return strconv.Quote(id), nil
+	default:
+		return "", fmt.Errorf("cannot encode ID of type %T as text", id)
+	}
+}
+
+// decodeIDText decodes a field written by encodeIDText into `id`.
+func decodeIDText[ID comparable](field string, id *ID) error {
+	var err error
+	switch target := any(id).(type) {
+	case *int:
+		*target, err = strconv.Atoi(field)
+	case *string:
+		*target, err = strconv.Unquote(field)
+	default:
+		err = fmt.Errorf("cannot decode ID of type %T from text", *id)
+	}
+	return err
+}
+
+// splitTextFields splits `text` on spaces, keeping quoted fields written by strconv.Quote intact.
+func splitTextFields(text string) ([]string, error) {
+	var fields []string
+	for text = strings.TrimSpace(text); text != ""; text = strings.TrimSpace(text) {
+		if text[0] == '"' {
+			field, err := strconv.QuotedPrefix(text)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field)
+			text = text[len(field):]
+			continue
+		}
+
+		end := strings.IndexAny(text, " \t\n")
+		if end < 0 {
+			end = len(text)
+		}
+		fields = append(fields, text[:end])
+		text = text[end:]
+	}
+	return fields, nil
+}
+
+// MarshalText encodes the match as `player1 player2 result`, followed by PlayedAt in RFC 3339 format if it is set.
+func (m Glicko2Match[ID]) MarshalText() ([]byte, error) {
+	player1, err := encodeIDText(m.Player1ID)
+	if err != nil {
+		return nil, err
+	}
+	player2, err := encodeIDText(m.Player2ID)
+	if err != nil {
+		return nil, err
+	}
+
+	text := []byte(player1 + " " + player2 + " ")
+	text = append(text, formatFloats(m.Result)...)
+	if !m.PlayedAt.IsZero() {
+		playedAt, err := m.PlayedAt.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		text = append(append(text, ' '), playedAt...)
+	}
+	return text, nil
+}
+
+func (m *Glicko2Match[ID]) UnmarshalText(text []byte) error {
+	fields, err := splitTextFields(string(text))
+	if err != nil {
+		return fmt.Errorf("cannot decode Glicko2Match: %w", err)
+	}
+	if len(fields) != 3 && len(fields) != 4 {
+		return fmt.Errorf("cannot decode Glicko2Match: expected 3 or 4 space separated values, got %v", len(fields))
+	}
+
+	var decoded Glicko2Match[ID]
+	if err := decodeIDText(fields[0], &decoded.Player1ID); err != nil {
+		return fmt.Errorf("cannot decode Glicko2Match: %w", err)
+	}
+	if err := decodeIDText(fields[1], &decoded.Player2ID); err != nil {
+		return fmt.Errorf("cannot decode Glicko2Match: %w", err)
+	}
+	if decoded.Result, err = strconv.ParseFloat(fields[2], 64); err != nil {
+		return fmt.Errorf("cannot decode Glicko2Match: %w", err)
+	}
+	if len(fields) == 4 {
+		if err := decoded.PlayedAt.UnmarshalText([]byte(fields[3])); err != nil {
+			return fmt.Errorf("cannot decode Glicko2Match: %w", err)
+		}
+	}
+
+	*m = decoded
+	return nil
+}
+
+// appendIDBinary appends `id` to `data`. Integers are written as varints, and strings are prefixed by their length.
+func appendIDBinary[ID comparable](data []byte, id ID) ([]byte, error) {
+	switch id := any(id).(type) {
+	case int:
+		return binary.AppendVarint(data, int64(id)), nil
+	case string:
+		return append(binary.AppendUvarint(data, uint64(len(id))), id...), nil
+	default:
+		return nil, fmt.Errorf("cannot encode ID of type %T as binary", id)
+	}
+}
+
+// readIDBinary decodes an ID written by appendIDBinary into `id`, returning the bytes that follow it.
+func readIDBinary[ID comparable](data []byte, id *ID) ([]byte, error) {
+	switch target := any(id).(type) {
+	case *int:
+		decoded, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, errors.New("data is truncated")
+		}
+		if decoded < math.MinInt || decoded > math.MaxInt {
+			return nil, fmt.Errorf("ID %v overflows int", decoded)
+		}
+		*target = int(decoded)
+		return data[n:], nil
+	case *string:
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			return nil, errors.New("data is truncated")
+		}
+		*target = string(data[n : n+int(length)])
+		return data[n+int(length):], nil
+	default:
+		return nil, fmt.Errorf("cannot decode ID of type %T from binary", *id)
+	}
+}
+
+// MarshalBinary encodes the match as both IDs, the IEEE 754 bits of Result, then PlayedAt as encoded by time.Time.MarshalBinary,
+// prefixed by its length (or a length of 0 if it is not set).
+func (m Glicko2Match[ID]) MarshalBinary() ([]byte, error) {
+	data, err := appendIDBinary(nil, m.Player1ID)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = appendIDBinary(data, m.Player2ID); err != nil {
+		return nil, err
+	}
+	data = appendFloatBits(data, m.Result)
+
+	var playedAt []byte
+	if !m.PlayedAt.IsZero() {
+		if playedAt, err = m.PlayedAt.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	return append(binary.AppendUvarint(data, uint64(len(playedAt))), playedAt...), nil
+}
+
+func (m *Glicko2Match[ID]) UnmarshalBinary(data []byte) error {
+	var decoded Glicko2Match[ID]
+	var err error
+
+	if data, err = readIDBinary(data, &decoded.Player1ID); err != nil {
+		return fmt.Errorf("cannot decode Glicko2Match: %w", err)
+	}
+	if data, err = readIDBinary(data, &decoded.Player2ID); err != nil {
+		return fmt.Errorf("cannot decode Glicko2Match: %w", err)
+	}
+	if len(data) < 8 {
+		return errors.New("cannot decode Glicko2Match: data is truncated")
+	}
+	decoded.Result = math.Float64frombits(binary.LittleEndian.Uint64(data))
+	data = data[8:]
+
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) != length {
+		return fmt.Errorf("cannot decode Glicko2Match: expected %v bytes of PlayedAt", length)
+	}
+	if length > 0 {
+		if err := decoded.PlayedAt.UnmarshalBinary(data[n:]); err != nil {
+			return fmt.Errorf("cannot decode Glicko2Match: %w", err)
+		}
+	}
+
+	*m = decoded
+	return nil
+}
+
+//// Settings
+
+// MarshalText encodes the settings as `system_constant convergence_tolerance max_iterations max_deviation disable_max_deviation`.
+func (s Glicko2AlgorithmSettings) MarshalText() ([]byte, error) {
+	text := formatFloats(s.SystemConstant, s.ConvergenceTolerance)
+	text = strconv.AppendInt(append(text, ' '), int64(s.MaxIterations), 10)
+	text = append(append(text, ' '), formatFloats(s.MaxDeviation)...)
+	return strconv.AppendBool(append(text, ' '), s.DisableMaxDeviation), nil
+}
+
+func (s *Glicko2AlgorithmSettings) UnmarshalText(text []byte) error {
+	fields := strings.Fields(string(text))
+	if len(fields) != 5 {
+		return fmt.Errorf("cannot decode Glicko2AlgorithmSettings: expected 5 space separated values, got %v", len(fields))
+	}
+
+	floats, err := parseFloats([]byte(fields[0]+" "+fields[1]+" "+fields[3]), 3)
+	if err != nil {
+		return fmt.Errorf("cannot decode Glicko2AlgorithmSettings: %w", err)
+	}
+	maxIterations, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return fmt.Errorf("cannot decode Glicko2AlgorithmSettings: %w", err)
+	}
+	disableMaxDeviation, err := strconv.ParseBool(fields[4])
+	if err != nil {
+		return fmt.Errorf("cannot decode Glicko2AlgorithmSettings: %w", err)
+	}
+
+	*s = Glicko2AlgorithmSettings{
+		SystemConstant:       floats[0],
+		ConvergenceTolerance: floats[1],
+		MaxIterations:        maxIterations,
+		MaxDeviation:         floats[2],
+		DisableMaxDeviation:  disableMaxDeviation,
+	}
+	return nil
+}
+
+// MarshalBinary encodes the IEEE 754 bits of SystemConstant, ConvergenceTolerance and MaxDeviation,
+// followed by MaxIterations as a little-endian int64 and a single byte for DisableMaxDeviation.
+func (s Glicko2AlgorithmSettings) MarshalBinary() ([]byte, error) {
+	data := appendFloatBits(make([]byte, 0, 33), s.SystemConstant, s.ConvergenceTolerance, s.MaxDeviation)
+	data = binary.LittleEndian.AppendUint64(data, uint64(int64(s.MaxIterations)))
+	if s.DisableMaxDeviation {
+		return append(data, 1), nil
+	}
+	return append(data, 0), nil
+}
+
+func (s *Glicko2AlgorithmSettings) UnmarshalBinary(data []byte) error {
+	if len(data) != 33 {
+		return fmt.Errorf("cannot decode Glicko2AlgorithmSettings: expected 33 bytes, got %v", len(data))
+	}
+	floats, err := readFloatBits(data[:24], 3)
+	if err != nil {
+		return fmt.Errorf("cannot decode Glicko2AlgorithmSettings: %w", err)
+	}
+	if data[32] > 1 {
+		return fmt.Errorf("cannot decode Glicko2AlgorithmSettings: invalid boolean byte %v", data[32])
+	}
+
+	maxIterations := int64(binary.LittleEndian.Uint64(data[24:]))
+	if maxIterations < math.MinInt || maxIterations > math.MaxInt {
+		return fmt.Errorf("cannot decode Glicko2AlgorithmSettings: MaxIterations %v overflows int", maxIterations)
+	}
+
+	*s = Glicko2AlgorithmSettings{
+		SystemConstant:       floats[0],
+		ConvergenceTolerance: floats[1],
+		MaxIterations:        int(maxIterations),
+		MaxDeviation:         floats[2],
+		DisableMaxDeviation:  data[32] == 1,
+	}
+	return nil
+}
+
+// MarshalText encodes the settings as `deviation_growth_constant max_deviation`.
+func (s GlickoAlgorithmSettings) MarshalText() ([]byte, error) {
+	return formatFloats(s.DeviationGrowthConstant, s.MaxDeviation), nil
+}
+
+func (s *GlickoAlgorithmSettings) UnmarshalText(text []byte) error {
+	values, err := parseFloats(text, 2)
+	if err != nil {
+		return fmt.Errorf("cannot decode GlickoAlgorithmSettings: %w", err)
+	}
+	*s = GlickoAlgorithmSettings{DeviationGrowthConstant: values[0], MaxDeviation: values[1]}
+	return nil
+}
+
+func (s GlickoAlgorithmSettings) MarshalBinary() ([]byte, error) {
+	return appendFloatBits(make([]byte, 0, 16), s.DeviationGrowthConstant, s.MaxDeviation), nil
+}
+
+func (s *GlickoAlgorithmSettings) UnmarshalBinary(data []byte) error {
+	values, err := readFloatBits(data, 2)
+	if err != nil {
+		return fmt.Errorf("cannot decode GlickoAlgorithmSettings: %w", err)
+	}
+	*s = GlickoAlgorithmSettings{DeviationGrowthConstant: values[0], MaxDeviation: values[1]}
+	return nil
+}
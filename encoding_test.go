@@ -0,0 +1,299 @@
+package glicko2go
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+// getEncodingTestPlayers returns players whose values are awkward to encode exactly.
+func getEncodingTestPlayers() []Glicko2Player {
+	return []Glicko2Player{
+		NewDefaultGlicko2Player(),
+		{
+			GlickoPlayer: GlickoPlayer{
+				Rating:          math.Nextafter(-0.2069, 0),
+				RatingDeviation: 1.0 / 3.0,
+			},
+			RatingVolatility: math.SmallestNonzeroFloat64,
+		},
+		{
+			GlickoPlayer: GlickoPlayer{
+				Rating:          math.Copysign(0, -1),
+				RatingDeviation: math.MaxFloat64,
+			},
+			RatingVolatility: 0.059995984286488495,
+		},
+	}
+}
+
+// playerBitsMatch returns true if both players hold identical bits for each value, including the sign of zero.
+func playerBitsMatch(a Glicko2Player, b Glicko2Player) bool {
+	return math.Float64bits(a.Rating) == math.Float64bits(b.Rating) &&
+		math.Float64bits(a.RatingDeviation) == math.Float64bits(b.RatingDeviation) &&
+		math.Float64bits(a.RatingVolatility) == math.Float64bits(b.RatingVolatility)
+}
+
+func TestPlayerJSONFieldNames(t *testing.T) {
+	encodedPlayer, err := json.Marshal(NewDefaultGlicko2Player())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"rating":0,"rating_deviation":2.014761872416068,"rating_volatility":0.06}`
+	if string(encodedPlayer) != expected {
+		t.Errorf("Unexpected JSON for a default player: \nExpected: %v\nGot:      %v", expected, string(encodedPlayer))
+	}
+
+	encodedGlickoPlayer, err := NewStandardScale().MarshalPlayerJSON(NewDefaultGlicko2Player())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"rating":1500,"rating_deviation":350,"rating_volatility":0.06}`
+	if string(encodedGlickoPlayer) != expected {
+		t.Errorf("Unexpected JSON for a default player on the Glicko scale: \nExpected: %v\nGot:      %v", expected, string(encodedGlickoPlayer))
+	}
+
+	encodedMatch, err := json.Marshal(Glicko2MatchByID{Player1ID: 1, Player2ID: 2, Result: GAME_OUTCOME_WIN})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"player1_id":1,"player2_id":2,"result":1}`
+	if string(encodedMatch) != expected {
+		t.Errorf("Unexpected JSON for a match: \nExpected: %v\nGot:      %v", expected, string(encodedMatch))
+	}
+}
+
+func TestPlayerEncodingRoundTrips(t *testing.T) {
+	for _, player := range getEncodingTestPlayers() {
+		encodedJSON, err := json.Marshal(player)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var jsonPlayer Glicko2Player
+		if err := json.Unmarshal(encodedJSON, &jsonPlayer); err != nil {
+			t.Fatal(err)
+		}
+		if !playerBitsMatch(player, jsonPlayer) {
+			t.Errorf("Player changes after a JSON round trip: \nBefore: %v\nAfter:  %v", player, jsonPlayer)
+		}
+
+		encodedText, err := player.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var textPlayer Glicko2Player
+		if err := textPlayer.UnmarshalText(encodedText); err != nil {
+			t.Fatal(err)
+		}
+		if !playerBitsMatch(player, textPlayer) {
+			t.Errorf("Player changes after a text round trip: \nBefore: %v\nAfter:  %v", player, textPlayer)
+		}
+
+		encodedBinary, err := player.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var binaryPlayer Glicko2Player
+		if err := binaryPlayer.UnmarshalBinary(encodedBinary); err != nil {
+			t.Fatal(err)
+		}
+		if !playerBitsMatch(player, binaryPlayer) {
+			t.Errorf("Player changes after a binary round trip: \nBefore: %v\nAfter:  %v", player, binaryPlayer)
+		}
+
+		encodedGlickoBinary, err := player.GlickoPlayer.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var binaryGlickoPlayer GlickoPlayer
+		if err := binaryGlickoPlayer.UnmarshalBinary(encodedGlickoBinary); err != nil {
+			t.Fatal(err)
+		}
+		if !playerBitsMatch(Glicko2Player{GlickoPlayer: player.GlickoPlayer}, Glicko2Player{GlickoPlayer: binaryGlickoPlayer}) {
+			t.Errorf("Glicko player changes after a binary round trip: \nBefore: %v\nAfter:  %v", player.GlickoPlayer, binaryGlickoPlayer)
+		}
+	}
+
+	var player Glicko2Player
+	if err := player.UnmarshalBinary(make([]byte, 16)); err == nil {
+		t.Errorf("Decoding a Glicko2Player from too few bytes did not cause an error")
+	}
+}
+
+func TestMatchJSONRoundTrip(t *testing.T) {
+	match := Glicko2Match[string]{
+		Player1ID: "a",
+		Player2ID: "b",
+		Result:    GAME_OUTCOME_DRAW,
+		PlayedAt:  time.Date(2024, time.March, 1, 12, 30, 0, 0, time.UTC),
+	}
+
+	encodedMatch, err := json.Marshal(match)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decodedMatch Glicko2Match[string]
+	if err := json.Unmarshal(encodedMatch, &decodedMatch); err != nil {
+		t.Fatal(err)
+	}
+	if decodedMatch != match {
+		t.Errorf("Match changes after a JSON round trip: \nBefore: %v\nAfter:  %v", match, decodedMatch)
+	}
+}
+
+// matchBitsMatch returns true if both matches hold the same IDs and instant, and identical bits for the result.
+func matchBitsMatch[ID comparable](a Glicko2Match[ID], b Glicko2Match[ID]) bool {
+	return a.Player1ID == b.Player1ID && a.Player2ID == b.Player2ID &&
+		math.Float64bits(a.Result) == math.Float64bits(b.Result) &&
+		a.PlayedAt.Equal(b.PlayedAt) && a.PlayedAt.IsZero() == b.PlayedAt.IsZero()
+}
+
+// checkMatchRoundTrips checks that each match keeps its exact values through text and binary encoding.
+func checkMatchRoundTrips[ID comparable](t *testing.T, matches []Glicko2Match[ID]) {
+	t.Helper()
+	for _, match := range matches {
+		encodedText, err := match.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var textMatch Glicko2Match[ID]
+		if err := textMatch.UnmarshalText(encodedText); err != nil {
+			t.Fatal(err)
+		}
+		if !matchBitsMatch(match, textMatch) {
+			t.Errorf("Match changes after a text round trip: \nBefore: %v\nAfter:  %v", match, textMatch)
+		}
+
+		encodedBinary, err := match.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var binaryMatch Glicko2Match[ID]
+		if err := binaryMatch.UnmarshalBinary(encodedBinary); err != nil {
+			t.Fatal(err)
+		}
+		if !matchBitsMatch(match, binaryMatch) {
+			t.Errorf("Match changes after a binary round trip: \nBefore: %v\nAfter:  %v", match, binaryMatch)
+		}
+	}
+}
+
+func TestMatchEncodingRoundTrips(t *testing.T) {
+	playedAt := time.Date(2024, time.March, 1, 12, 30, 0, 123456789, time.FixedZone("UTC+5:30", 5*60*60+30*60))
+
+	checkMatchRoundTrips(t, []Glicko2Match[int]{
+		{Player1ID: 1, Player2ID: 2, Result: GAME_OUTCOME_WIN},
+		{Player1ID: -7, Player2ID: math.MaxInt, Result: 1.0 / 3.0, PlayedAt: playedAt},
+		{Player1ID: math.MinInt, Player2ID: 0, Result: math.Copysign(0, -1)},
+	})
+	checkMatchRoundTrips(t, []Glicko2Match[string]{
+		{Player1ID: "a", Player2ID: "b", Result: GAME_OUTCOME_DRAW, PlayedAt: playedAt.UTC()},
+		{Player1ID: "player one", Player2ID: `"quoted" \ id`, Result: GAME_OUTCOME_LOSS},
+		{Player1ID: "", Player2ID: "ünïcödé", Result: math.SmallestNonzeroFloat64},
+	})
+
+	var match Glicko2Match[int]
+	if err := match.UnmarshalText([]byte("1 99999999999999999999 1")); err == nil {
+		t.Errorf("Decoding a Glicko2Match with an out of range ID did not cause an error")
+	}
+	encodedBinary, err := Glicko2Match[int]{Player1ID: 1, Player2ID: 2, PlayedAt: playedAt}.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var binaryMatch Glicko2Match[int]
+	if err := binaryMatch.UnmarshalBinary(encodedBinary[:len(encodedBinary)-1]); err == nil {
+		t.Errorf("Decoding a Glicko2Match from too few bytes did not cause an error")
+	}
+	if _, err := (Glicko2Match[float64]{}).MarshalText(); err == nil {
+		t.Errorf("Encoding a Glicko2Match with float IDs as text did not cause an error")
+	}
+	if _, err := (Glicko2Match[uint8]{}).MarshalBinary(); err == nil {
+		t.Errorf("Encoding a Glicko2Match with uint8 IDs as binary did not cause an error")
+	}
+}
+
+func TestSettingsEncodingRoundTrips(t *testing.T) {
+	settingsList := []Glicko2AlgorithmSettings{
+		{},
+		glicko2DefaultSettings,
+		{SystemConstant: 1.0 / 3.0, ConvergenceTolerance: math.SmallestNonzeroFloat64, MaxIterations: math.MaxInt, MaxDeviation: math.Copysign(0, -1)},
+		{SystemConstant: 0.2, MaxIterations: -1, DisableMaxDeviation: true},
+	}
+
+	for _, settings := range settingsList {
+		encodedJSON, err := json.Marshal(settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var jsonSettings Glicko2AlgorithmSettings
+		if err := json.Unmarshal(encodedJSON, &jsonSettings); err != nil {
+			t.Fatal(err)
+		}
+
+		encodedText, err := settings.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var textSettings Glicko2AlgorithmSettings
+		if err := textSettings.UnmarshalText(encodedText); err != nil {
+			t.Fatal(err)
+		}
+
+		encodedBinary, err := settings.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var binarySettings Glicko2AlgorithmSettings
+		if err := binarySettings.UnmarshalBinary(encodedBinary); err != nil {
+			t.Fatal(err)
+		}
+
+		for encoding, decoded := range map[string]Glicko2AlgorithmSettings{"JSON": jsonSettings, "text": textSettings, "binary": binarySettings} {
+			if math.Float64bits(settings.SystemConstant) != math.Float64bits(decoded.SystemConstant) ||
+				math.Float64bits(settings.ConvergenceTolerance) != math.Float64bits(decoded.ConvergenceTolerance) ||
+				math.Float64bits(settings.MaxDeviation) != math.Float64bits(decoded.MaxDeviation) ||
+				settings.MaxIterations != decoded.MaxIterations || settings.DisableMaxDeviation != decoded.DisableMaxDeviation {
+				t.Errorf("Settings change after a %v round trip: \nBefore: %+v\nAfter:  %+v", encoding, settings, decoded)
+			}
+		}
+	}
+
+	glickoSettings := GlickoAlgorithmSettings{DeviationGrowthConstant: 34.6, MaxDeviation: 1.0 / 3.0}
+	encodedText, err := glickoSettings.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var textGlickoSettings GlickoAlgorithmSettings
+	if err := textGlickoSettings.UnmarshalText(encodedText); err != nil {
+		t.Fatal(err)
+	}
+	encodedBinary, err := glickoSettings.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var binaryGlickoSettings GlickoAlgorithmSettings
+	if err := binaryGlickoSettings.UnmarshalBinary(encodedBinary); err != nil {
+		t.Fatal(err)
+	}
+	if textGlickoSettings != glickoSettings || binaryGlickoSettings != glickoSettings {
+		t.Errorf("Glicko settings change after a round trip: \nBefore: %+v\nText:   %+v\nBinary: %+v", glickoSettings, textGlickoSettings, binaryGlickoSettings)
+	}
+
+	var settings Glicko2AlgorithmSettings
+	if err := settings.UnmarshalBinary(make([]byte, 32)); err == nil {
+		t.Errorf("Decoding Glicko2AlgorithmSettings from too few bytes did not cause an error")
+	}
+	if err := settings.UnmarshalText([]byte("0.5 1e-06 99999999999999999999 0 false")); err == nil {
+		t.Errorf("Decoding Glicko2AlgorithmSettings with an out of range MaxIterations did not cause an error")
+	}
+
+	encodedSettings, err := json.Marshal(glicko2DefaultSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encodedSettings) == 0 || encodedSettings[0] != '{' {
+		t.Errorf("Settings are not encoded as a JSON object: %s", encodedSettings)
+	}
+}
@@ -0,0 +1,139 @@
+// Package evaluation measures how well ratings predict match outcomes, so the health of a rating model can be tracked per period.
+//
+// Predictions are made with glicko2go.Glicko2Player.WinProbability from players as they were before the period,
+// and are scored against each match's result, where a draw counts as half a win.
+package evaluation
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Too-Zestyy/glicko2go"
+)
+
+// DEFAULT_CALIBRATION_BUCKETS is the number of calibration buckets used when Options.CalibrationBuckets is 0.
+const DEFAULT_CALIBRATION_BUCKETS = 10
+
+// Options holds the settings used by Evaluate.
+type Options struct {
+	// CalibrationBuckets is the number of equal-width buckets that predictions are split into. If 0, DEFAULT_CALIBRATION_BUCKETS is used.
+	CalibrationBuckets int
+}
+
+// CalibrationBucket compares the predictions within [Lower, Upper) to the results they predicted.
+// The final bucket also includes predictions equal to Upper.
+type CalibrationBucket struct {
+	Lower   float64 `json:"lower"`
+	Upper   float64 `json:"upper"`
+	Matches int     `json:"matches"`
+	// MeanPredicted is the mean predicted score of the matches within the bucket, or 0 if it is empty.
+	MeanPredicted float64 `json:"mean_predicted"`
+	// MeanObserved is the mean result of the matches within the bucket, or 0 if it is empty.
+	MeanObserved float64 `json:"mean_observed"`
+}
+
+// Report holds the prediction quality of a single set of matches.
+type Report struct {
+	Matches int `json:"matches"`
+	// LogLoss is the mean log-loss of every prediction. Lower is better, and always predicting 0.5 scores ln 2.
+	LogLoss float64 `json:"log_loss"`
+	// BrierScore is the mean squared error of every prediction. Lower is better, and always predicting 0.5 scores 0.25.
+	BrierScore float64 `json:"brier_score"`
+	// Accuracy is the fraction of AccuracyMatches whose winner was favoured by its prediction.
+	Accuracy float64 `json:"accuracy"`
+	// AccuracyMatches excludes draws, and matches where neither player was favoured.
+	AccuracyMatches int                 `json:"accuracy_matches"`
+	Calibration     []CalibrationBucket `json:"calibration"`
+}
+
+// Evaluate predicts the result of every match from `players`, which should be the players before the matches' period,
+// then scores the predictions against the actual results.
+//
+// Any invalid match causes a *glicko2go.MatchValidationError to be returned, as with the period calculators.
+// A period without any matches produces a report with zero Matches and scores, rather than an error.
+func Evaluate[ID comparable](players map[ID]glicko2go.Glicko2Player, matches []glicko2go.Glicko2Match[ID], options Options) (Report, error) {
+	if options.CalibrationBuckets < 0 {
+		return Report{}, fmt.Errorf("calibration buckets cannot be negative, got %v", options.CalibrationBuckets)
+	}
+	if options.CalibrationBuckets == 0 {
+		options.CalibrationBuckets = DEFAULT_CALIBRATION_BUCKETS
+	}
+
+	if issues := glicko2go.ValidateMatches(players, matches); len(issues) > 0 {
+		return Report{}, &glicko2go.MatchValidationError[ID]{Issues: issues}
+	}
+	report := Report{
+		Matches:     len(matches),
+		Calibration: make([]CalibrationBucket, options.CalibrationBuckets),
+	}
+	for idx := range report.Calibration {
+		report.Calibration[idx].Lower = float64(idx) / float64(options.CalibrationBuckets)
+		report.Calibration[idx].Upper = float64(idx+1) / float64(options.CalibrationBuckets)
+	}
+
+	correct := 0
+	for _, match := range matches {
+		prediction := players[match.Player1ID].WinProbability(players[match.Player2ID])
+
+		// Shared with glicko2go.TuneSystemConstant, so scores are comparable with its log-loss
+		report.LogLoss += glicko2go.PredictionLogLoss(prediction, match.Result)
+		report.BrierScore += math.Pow(prediction-match.Result, 2)
+
+		if prediction != 0.5 && match.Result != glicko2go.GAME_OUTCOME_DRAW {
+			report.AccuracyMatches++
+			if (prediction > 0.5) == (match.Result > glicko2go.GAME_OUTCOME_DRAW) {
+				correct++
+			}
+		}
+
+		bucket := &report.Calibration[min(int(prediction*float64(options.CalibrationBuckets)), options.CalibrationBuckets-1)]
+		bucket.Matches++
+		bucket.MeanPredicted += prediction
+		bucket.MeanObserved += match.Result
+	}
+
+	if report.Matches > 0 {
+		report.LogLoss /= float64(report.Matches)
+		report.BrierScore /= float64(report.Matches)
+	}
+	if report.AccuracyMatches > 0 {
+		report.Accuracy = float64(correct) / float64(report.AccuracyMatches)
+	}
+	for idx := range report.Calibration {
+		if bucket := &report.Calibration[idx]; bucket.Matches > 0 {
+			bucket.MeanPredicted /= float64(bucket.Matches)
+			bucket.MeanObserved /= float64(bucket.Matches)
+		}
+	}
+
+	return report, nil
+}
+
+// WriteTable writes the report as a human-readable table, followed by a row for each non-empty calibration bucket.
+func (r Report) WriteTable(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(writer, "Matches\tLog-loss\tBrier\tAccuracy\t")
+	fmt.Fprintf(writer, "%v\t%.4f\t%.4f\t%.2f%%\t\n", r.Matches, r.LogLoss, r.BrierScore, r.Accuracy*100)
+	fmt.Fprintln(writer, "\t\t\t\t")
+
+	fmt.Fprintln(writer, "Predicted\tMatches\tMean predicted\tMean observed\t")
+	for _, bucket := range r.Calibration {
+		if bucket.Matches == 0 {
+			continue
+		}
+		fmt.Fprintf(writer, "%.2f-%.2f\t%v\t%.4f\t%.4f\t\n", bucket.Lower, bucket.Upper, bucket.Matches, bucket.MeanPredicted, bucket.MeanObserved)
+	}
+
+	return writer.Flush()
+}
+
+// String returns the report's table. See Report.WriteTable.
+func (r Report) String() string {
+	var builder strings.Builder
+	r.WriteTable(&builder)
+	return builder.String()
+}
@@ -0,0 +1,130 @@
+package evaluation
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Too-Zestyy/glicko2go"
+)
+
+// getExamplePlayers returns the players from the example at https://www.glicko.net/glicko/glicko2.pdf.
+func getExamplePlayers() map[int]glicko2go.Glicko2Player {
+	return map[int]glicko2go.Glicko2Player{
+		1: glicko2go.ConvertToGlicko2WithDefaultVolatility(glicko2go.GlickoPlayer{Rating: 1500, RatingDeviation: 200}),
+		2: glicko2go.ConvertToGlicko2WithDefaultVolatility(glicko2go.GlickoPlayer{Rating: 1400, RatingDeviation: 30}),
+		3: glicko2go.ConvertToGlicko2WithDefaultVolatility(glicko2go.GlickoPlayer{Rating: 1550, RatingDeviation: 100}),
+		4: glicko2go.ConvertToGlicko2WithDefaultVolatility(glicko2go.GlickoPlayer{Rating: 1700, RatingDeviation: 300}),
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	players := getExamplePlayers()
+	matches := []glicko2go.Glicko2MatchByID{
+		{Player1ID: 1, Player2ID: 2, Result: glicko2go.GAME_OUTCOME_WIN},
+		{Player1ID: 1, Player2ID: 3, Result: glicko2go.GAME_OUTCOME_LOSS},
+		{Player1ID: 4, Player2ID: 2, Result: glicko2go.GAME_OUTCOME_LOSS},
+		{Player1ID: 3, Player2ID: 2, Result: glicko2go.GAME_OUTCOME_DRAW},
+	}
+
+	report, err := Evaluate(players, matches, Options{CalibrationBuckets: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expectedLogLoss, expectedBrier float64
+	for _, match := range matches {
+		prediction := players[match.Player1ID].WinProbability(players[match.Player2ID])
+		expectedLogLoss -= match.Result*math.Log(prediction) + (1-match.Result)*math.Log(1-prediction)
+		expectedBrier += math.Pow(prediction-match.Result, 2)
+	}
+	expectedLogLoss /= float64(len(matches))
+	expectedBrier /= float64(len(matches))
+
+	if report.Matches != len(matches) {
+		t.Errorf("Expected %v matches, got %v", len(matches), report.Matches)
+	}
+	if math.Abs(report.LogLoss-expectedLogLoss) > 1e-12 || math.Abs(report.BrierScore-expectedBrier) > 1e-12 {
+		t.Errorf("Expected a log-loss of %v and Brier score of %v, got %v and %v", expectedLogLoss, expectedBrier, report.LogLoss, report.BrierScore)
+	}
+
+	// The draw is excluded, player 1 beating player 2 and player 3 beating player 1 were favoured, and player 4 losing was not
+	if report.AccuracyMatches != 3 || math.Abs(report.Accuracy-2.0/3) > 1e-12 {
+		t.Errorf("Expected an accuracy of 2/3 over 3 matches, got %v over %v", report.Accuracy, report.AccuracyMatches)
+	}
+
+	if len(report.Calibration) != 4 {
+		t.Fatalf("Expected 4 calibration buckets, got %v", len(report.Calibration))
+	}
+	bucketedMatches := 0
+	for _, bucket := range report.Calibration {
+		bucketedMatches += bucket.Matches
+		if bucket.Matches > 0 && (bucket.MeanPredicted < bucket.Lower || bucket.MeanPredicted > bucket.Upper) {
+			t.Errorf("Bucket %+v has a mean prediction outside of its range", bucket)
+		}
+	}
+	if bucketedMatches != len(matches) {
+		t.Errorf("Expected every match to be in a calibration bucket, got %v", bucketedMatches)
+	}
+}
+
+func TestEvaluateCalibrationEdges(t *testing.T) {
+	players := map[int]glicko2go.Glicko2Player{
+		1: glicko2go.NewDefaultGlicko2Player(),
+		2: glicko2go.NewDefaultGlicko2Player(),
+	}
+	matches := []glicko2go.Glicko2MatchByID{{Player1ID: 1, Player2ID: 2, Result: glicko2go.GAME_OUTCOME_WIN}}
+
+	report, err := Evaluate(players, matches, Options{CalibrationBuckets: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Identical players predict exactly 0.5, which starts the second bucket, and favours neither player
+	if report.Calibration[1].Matches != 1 || report.Calibration[1].MeanObserved != glicko2go.GAME_OUTCOME_WIN {
+		t.Errorf("Expected the match in the second bucket, got %+v", report.Calibration)
+	}
+	if report.AccuracyMatches != 0 {
+		t.Errorf("A match without a favourite was counted towards accuracy")
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	var validationErr *glicko2go.MatchValidationError[int]
+	_, err := Evaluate(getExamplePlayers(), []glicko2go.Glicko2MatchByID{{Player1ID: 1, Player2ID: 5}}, Options{})
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected a *MatchValidationError for an unknown player, got %v", err)
+	}
+
+	if _, err := Evaluate(getExamplePlayers(), []glicko2go.Glicko2MatchByID{{Player1ID: 1, Player2ID: 2}}, Options{CalibrationBuckets: -1}); err == nil {
+		t.Errorf("A negative number of calibration buckets did not cause an error")
+	}
+}
+
+func TestEvaluateWithoutMatches(t *testing.T) {
+	report, err := Evaluate(getExamplePlayers(), nil, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Matches != 0 || report.LogLoss != 0 || report.BrierScore != 0 || report.Accuracy != 0 || report.AccuracyMatches != 0 {
+		t.Errorf("Expected a zero report for a period without matches, got %+v", report)
+	}
+	if len(report.Calibration) != DEFAULT_CALIBRATION_BUCKETS {
+		t.Errorf("Expected %v empty calibration buckets, got %v", DEFAULT_CALIBRATION_BUCKETS, len(report.Calibration))
+	}
+}
+
+func TestReportTable(t *testing.T) {
+	report, err := Evaluate(getExamplePlayers(), []glicko2go.Glicko2MatchByID{{Player1ID: 1, Player2ID: 2, Result: glicko2go.GAME_OUTCOME_WIN}}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := report.String()
+	for _, expected := range []string{"Log-loss", "Brier", "Accuracy", "Mean observed", "100.00%"} {
+		if !strings.Contains(table, expected) {
+			t.Errorf("Table does not contain %q:\n%v", expected, table)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package glicko2go
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// GLICKO_DEFAULT_DEVIATION_GROWTH_CONSTANT is the value of `c` used within the Glicko paper's example,
+	// where a player's deviation takes 100 periods to grow from 50 to GLICKO_DEFAULT_PLAYER_DEVIATION.
+	GLICKO_DEFAULT_DEVIATION_GROWTH_CONSTANT float64 = 34.6
+)
+
+var (
+	// glickoQ is `q` as defined in step 2.
+	glickoQ = math.Ln10 / 400
+
+	glickoDefaultSettings GlickoAlgorithmSettings = GlickoAlgorithmSettings{
+		DeviationGrowthConstant: GLICKO_DEFAULT_DEVIATION_GROWTH_CONSTANT,
+		MaxDeviation:            GLICKO_DEFAULT_PLAYER_DEVIATION,
+	}
+)
+
+//// Onset deviation (Step 1)
+
+// glickoOnsetDeviation calculates a player's deviation at the start of a period, capped at `maxDeviation`.
+func glickoOnsetDeviation(deviation float64, deviationGrowthConstant float64, maxDeviation float64) float64 {
+	return math.Min(math.Sqrt(math.Pow(deviation, 2)+math.Pow(deviationGrowthConstant, 2)), maxDeviation)
+}
+
+//// Rating update (Step 2)
+
+// glickoG Calculates `g(RD)`, which reduces the impact of games against opponents with uncertain ratings.
+func glickoG(deviation float64) float64 {
+	return 1 / math.Sqrt(1+3*math.Pow(glickoQ, 2)*math.Pow(deviation, 2)/piSquared)
+}
+
+// glickoE Calculates `E(s|r, rj, RDj)`, the expected outcome of a game against an opponent.
+func glickoE(rating float64, opponentRating float64, opponentDeviation float64) float64 {
+	return 1 / (1 + math.Pow(10, -glickoG(opponentDeviation)*(rating-opponentRating)/400))
+}
+
+// glickoDSquared Calculates `d²`, the variance of a player's rating from game outcomes alone.
+func glickoDSquared(playerRating float64, opponentRatings []float64, opponentDeviations []float64) float64 {
+	var sum float64
+
+	for i := 0; i < len(opponentRatings); i++ {
+		curMatchE := glickoE(playerRating, opponentRatings[i], opponentDeviations[i])
+		sum += math.Pow(glickoG(opponentDeviations[i]), 2) * curMatchE * (1 - curMatchE)
+	}
+
+	return 1 / (math.Pow(glickoQ, 2) * sum)
+}
+
+// UpdateGlickoPlayerFromMatches Calculates a player's new rating and deviation after a single period of the original Glicko system.
+// The player's deviation is first grown by `settings.DeviationGrowthConstant` (step 1), then updated from the period's games (step 2).
+//
+// For more details, see https://www.glicko.net/glicko/glicko.pdf
+func UpdateGlickoPlayerFromMatches(playerRating float64, playerDeviation float64, opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64,
+	settings GlickoAlgorithmSettings) (float64, float64, error) {
+
+	// Argument Validation
+	if err := settings.Validate(); err != nil {
+		return -1, -1, err
+	}
+	if len(opponentRatings) != len(opponentDeviations) || len(opponentRatings) != len(gameOutcomes) {
+		return -1, -1, errors.New("the lengths of opponent ratings, deviations and game outcomes must be the same length")
+	}
+
+	onsetDeviation := glickoOnsetDeviation(playerDeviation, settings.DeviationGrowthConstant, settings.maxDeviation())
+
+	if len(gameOutcomes) == 0 {
+		return playerRating, onsetDeviation, nil
+	}
+
+	dSquared := glickoDSquared(playerRating, opponentRatings, opponentDeviations)
+	precision := 1/math.Pow(onsetDeviation, 2) + 1/dSquared
+
+	var sum float64
+	for i := 0; i < len(opponentRatings); i++ {
+		sum += glickoG(opponentDeviations[i]) * (gameOutcomes[i] - glickoE(playerRating, opponentRatings[i], opponentDeviations[i]))
+	}
+
+	return playerRating + glickoQ/precision*sum, math.Sqrt(1 / precision), nil
+}
+
+//// Convenience functions
+
+// GlickoPlayerUpdaterWithSettings returns a function used to update a GlickoPlayer after a period of the original Glicko system.
+func GlickoPlayerUpdaterWithSettings(settings GlickoAlgorithmSettings) func(player GlickoPlayer, periodGames []GlickoMatchForPlayer) (GlickoPlayer, error) {
+
+	return func(player GlickoPlayer, periodGames []GlickoMatchForPlayer) (GlickoPlayer, error) {
+		opponentRatings := make([]float64, 0, len(periodGames))
+		opponentDeviations := make([]float64, 0, len(periodGames))
+		gameResults := make([]float64, 0, len(periodGames))
+
+		for _, game := range periodGames {
+			opponentRatings = append(opponentRatings, game.Opponent.Rating)
+			opponentDeviations = append(opponentDeviations, game.Opponent.RatingDeviation)
+			gameResults = append(gameResults, game.Result)
+		}
+
+		newRating, newDeviation, err := UpdateGlickoPlayerFromMatches(player.Rating, player.RatingDeviation, opponentRatings, opponentDeviations, gameResults, settings)
+		if err != nil {
+			return GlickoPlayer{}, err
+		}
+
+		return GlickoPlayer{
+			Rating:          newRating,
+			RatingDeviation: newDeviation,
+		}, nil
+	}
+}
+
+// GlickoPlayerUpdaterWithDefaultSettings provides default settings for GlickoPlayerUpdaterWithSettings:
+//   - Deviation growth constant: GLICKO_DEFAULT_DEVIATION_GROWTH_CONSTANT
+//   - Max deviation: GLICKO_DEFAULT_PLAYER_DEVIATION
+func GlickoPlayerUpdaterWithDefaultSettings() func(player GlickoPlayer, periodGames []GlickoMatchForPlayer) (GlickoPlayer, error) {
+	return GlickoPlayerUpdaterWithSettings(glickoDefaultSettings)
+}
+
+// GenericGlickoPeriodCalculatorWithSettings returns a function that calculates every player's stats after a period of the original Glicko system,
+// where players are identified by any comparable `ID` type. Matches are validated in the same way as GenericPeriodCalculatorWithSettings.
+func GenericGlickoPeriodCalculatorWithSettings[ID comparable](settings GlickoAlgorithmSettings) func(
+	players map[ID]GlickoPlayer,
+	matches []Glicko2Match[ID]) (map[ID]GlickoPlayer, error) {
+
+	playerUpdater := GlickoPlayerUpdaterWithSettings(settings)
+
+	return func(players map[ID]GlickoPlayer, matches []Glicko2Match[ID]) (map[ID]GlickoPlayer, error) {
+
+		if issues := ValidateMatches(players, matches); len(issues) > 0 {
+			return nil, &MatchValidationError[ID]{Issues: issues}
+		}
+
+		newMatchLists := make(map[ID][]GlickoMatchForPlayer)
+
+		for _, match := range matches {
+			newMatchLists[match.Player1ID] = append(newMatchLists[match.Player1ID], GlickoMatchForPlayer{
+				Opponent: players[match.Player2ID],
+				Result:   match.Result,
+			})
+
+			newMatchLists[match.Player2ID] = append(newMatchLists[match.Player2ID], GlickoMatchForPlayer{
+				Opponent: players[match.Player1ID],
+				Result:   1 - match.Result,
+			})
+		}
+
+		updatedPlayers := make(map[ID]GlickoPlayer, len(players))
+
+		for playerID, player := range players {
+			updatedPlayer, err := playerUpdater(player, newMatchLists[playerID])
+			if err != nil {
+				return nil, err
+			}
+
+			updatedPlayers[playerID] = updatedPlayer
+		}
+
+		return updatedPlayers, nil
+	}
+}
+
+// GlickoPeriodCalculatorWithSettings is GenericGlickoPeriodCalculatorWithSettings for `int` player IDs.
+func GlickoPeriodCalculatorWithSettings(settings GlickoAlgorithmSettings) func(
+	players map[int]GlickoPlayer,
+	matches []Glicko2MatchByID) (map[int]GlickoPlayer, error) {
+	return GenericGlickoPeriodCalculatorWithSettings[int](settings)
+}
+
+// DefaultGlickoPeriodCalculator provides default settings for GlickoPeriodCalculatorWithSettings,
+// in an identical fashion to GlickoPlayerUpdaterWithDefaultSettings.
+func DefaultGlickoPeriodCalculator() func(players map[int]GlickoPlayer, matches []Glicko2MatchByID) (map[int]GlickoPlayer, error) {
+	return GlickoPeriodCalculatorWithSettings(glickoDefaultSettings)
+}
@@ -4,6 +4,7 @@ package glicko2go
 
 import (
 	"errors"
+	"fmt"
 	"math"
 )
 
@@ -24,6 +25,8 @@ var (
 	glicko2DefaultSettings Glicko2AlgorithmSettings = Glicko2AlgorithmSettings{
 		SystemConstant:       GLICKO2_DEFAULT_SYSTEM_CONSTANT,
 		ConvergenceTolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE,
+		MaxIterations:        GLICKO2_DEFAULT_MAX_ITERATIONS,
+		MaxDeviation:         GLICKO2_DEFAULT_MAX_DEVIATION,
 	}
 )
 
@@ -86,7 +89,10 @@ func fVolatilityFunction(x float64, systemConstant float64, volatility float64,
 }
 
 // calculateNewVolatility Calculates the post-period volatility of a player (AKA `σ′`).
-func calculateNewVolatility(convergenceTolerance float64, systemConstant float64, volatility float64, delta float64, deviation float64, variance float64) float64 {
+// Returns ErrVolatilityDidNotConverge if either the bracketing or the iteration exceeds `maxIterations` steps.
+// If `trace` is not nil, the bracketing values and every iteration are recorded to it.
+func calculateNewVolatility(convergenceTolerance float64, systemConstant float64, maxIterations int, volatility float64, delta float64, deviation float64, variance float64,
+	trace *Glicko2VolatilityTrace) (float64, error) {
 	a := aFromVolatility(volatility)
 
 	// Get bracketing values to speed up convergence
@@ -98,18 +104,32 @@ func calculateNewVolatility(convergenceTolerance float64, systemConstant float64
 		var k float64 = 1
 		for {
 			if fVolatilityFunction(a-k*systemConstant, systemConstant, volatility, delta, deviation, variance) < 0 {
+				if int(k) >= maxIterations {
+					return -1, ErrVolatilityDidNotConverge
+				}
 				k += 1
 			} else {
 				break
 			}
 		}
 		B = a - k*systemConstant
+		if trace != nil {
+			trace.K = k
+		}
 	}
 
 	fA := fVolatilityFunction(A, systemConstant, volatility, delta, deviation, variance)
 	fB := fVolatilityFunction(B, systemConstant, volatility, delta, deviation, variance)
 
-	for {
+	if trace != nil {
+		trace.LowerA = a
+		trace.InitialA = A
+		trace.InitialB = B
+		trace.InitialFA = fA
+		trace.InitialFB = fB
+	}
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
 		if math.Abs(B-A) > convergenceTolerance {
 
 			C := A + (A-B)*fA/(fB-fA)
@@ -126,20 +146,25 @@ func calculateNewVolatility(convergenceTolerance float64, systemConstant float64
 			B = C
 			fB = fC
 
+			if trace != nil {
+				trace.Iterations = append(trace.Iterations, Glicko2VolatilityIteration{A: A, B: B, C: C, FA: fA, FB: fB, FC: fC})
+			}
+
 		} else {
-			return math.Exp(A / 2)
+			return math.Exp(A / 2), nil
 		}
 	}
 
+	return -1, ErrVolatilityDidNotConverge
 }
 
 // calcVolatilityFromMatches is a convenience function to calculate volatility from a list of matches, without needing intermediate values.
 func calcVolatilityFromMatches(playerRating float64, playerDeviation float64, playerVolatility float64, periodVariance float64, opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64,
-	convergenceTolerance float64, systemConstant float64) float64 {
+	convergenceTolerance float64, systemConstant float64, maxIterations int, trace *Glicko2VolatilityTrace) (float64, error) {
 
 	delta := calculateEstimatedRatingImprovement(playerRating, opponentRatings, opponentDeviations, gameOutcomes, periodVariance)
 
-	return calculateNewVolatility(convergenceTolerance, systemConstant, playerVolatility, delta, playerDeviation, periodVariance)
+	return calculateNewVolatility(convergenceTolerance, systemConstant, maxIterations, playerVolatility, delta, playerDeviation, periodVariance, trace)
 
 }
 
@@ -148,14 +173,20 @@ func calcVolatilityFromMatches(playerRating float64, playerDeviation float64, pl
 // calcPreRatingDeviation calculates the deviation value that is both used as a component for post-period volatility,
 // and to update volatility when players have not played a game within a period.
 func calcPreRatingDeviation(deviation float64, volatility float64) float64 {
-	return math.Sqrt(math.Pow(deviation, 2) + math.Pow(volatility, 2))
+	return calcElapsedPreRatingDeviation(deviation, volatility, 1)
+}
+
+// calcElapsedPreRatingDeviation generalises calcPreRatingDeviation to any number of elapsed periods (AKA `t`),
+// including fractions of a period, as `√(φ² + tσ²)`.
+func calcElapsedPreRatingDeviation(deviation float64, volatility float64, elapsedPeriods float64) float64 {
+	return math.Sqrt(math.Pow(deviation, 2) + elapsedPeriods*math.Pow(volatility, 2))
 }
 
 //// Update player stats (Step 7)
 
 // calcPlayedPeriodDeviation Calculates the post-period volatility for a player when matches have been played within the period.
-func calcPlayedPeriodDeviation(playerDeviation float64, variance float64, newVolatility float64) float64 {
-	return 1 / math.Sqrt((1/math.Pow(calcPreRatingDeviation(playerDeviation, newVolatility), 2))+(1/variance))
+func calcPlayedPeriodDeviation(preRatingDeviation float64, variance float64) float64 {
+	return 1 / math.Sqrt((1/math.Pow(preRatingDeviation, 2))+(1/variance))
 }
 
 func calcPlayedPeriodRating(playerRating float64, postPeriodDeviation float64, opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64) float64 {
@@ -170,28 +201,75 @@ func calcPlayedPeriodRating(playerRating float64, postPeriodDeviation float64, o
 
 // UpdatePlayerFromMatches Calculates a players new rating, deviation and volatility after a single period.
 //
+// Returns an error wrapping ErrInvalidSettings if `settings` fails validation,
+// or ErrVolatilityDidNotConverge if the volatility solver exceeds `settings.MaxIterations`.
+//
 // For more details, see https://www.glicko.net/glicko/glicko2.pdf
 func UpdatePlayerFromMatches(playerRating float64, playerDeviation float64, playerVolatility float64, opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64, settings Glicko2AlgorithmSettings) (float64, float64, float64, error) {
+	return updatePlayerFromMatches(playerRating, playerDeviation, playerVolatility, opponentRatings, opponentDeviations, gameOutcomes, 1, settings, nil)
+}
+
+// UpdatePlayerFromMatchesAfterElapsed is equivalent to UpdatePlayerFromMatches, but grows the player's deviation
+// by `elapsedPeriods` (AKA `t`) rather than a single period, as `√(φ² + tσ²)`. Fractions of a period are allowed,
+// allowing ratings to be updated instantly rather than at the end of a fixed period.
+func UpdatePlayerFromMatchesAfterElapsed(playerRating float64, playerDeviation float64, playerVolatility float64, opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64,
+	elapsedPeriods float64, settings Glicko2AlgorithmSettings) (float64, float64, float64, error) {
+	return updatePlayerFromMatches(playerRating, playerDeviation, playerVolatility, opponentRatings, opponentDeviations, gameOutcomes, elapsedPeriods, settings, nil)
+}
+
+// updatePlayerFromMatches implements UpdatePlayerFromMatchesAfterElapsed, recording every intermediate value to `trace` if it is not nil.
+func updatePlayerFromMatches(playerRating float64, playerDeviation float64, playerVolatility float64, opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64,
+	elapsedPeriods float64, settings Glicko2AlgorithmSettings, trace *Glicko2CalculationTrace) (float64, float64, float64, error) {
 
 	// Argument Validation
+	if err := settings.Validate(); err != nil {
+		return -1, -1, -1, err
+	}
 	if len(opponentRatings) != len(opponentDeviations) || len(opponentRatings) != len(gameOutcomes) || len(opponentDeviations) != len(gameOutcomes) {
 		return -1, -1, -1, errors.New("the lengths of opponent ratings, deviations and game outcomes must be the same length")
 	}
+	if !(elapsedPeriods >= 0) || math.IsInf(elapsedPeriods, 1) {
+		return -1, -1, -1, fmt.Errorf("elapsed periods must be non-negative and finite, got %v", elapsedPeriods)
+	}
+
+	if trace != nil {
+		trace.recordInputs(playerRating, playerDeviation, playerVolatility, opponentRatings, opponentDeviations, gameOutcomes, elapsedPeriods, settings)
+	}
 
 	if len(gameOutcomes) == 0 {
-		return playerRating, calcPreRatingDeviation(playerDeviation, playerVolatility), playerVolatility, nil
+		newDeviation := math.Min(calcElapsedPreRatingDeviation(playerDeviation, playerVolatility, elapsedPeriods), settings.maxDeviation())
+		if trace != nil {
+			trace.recordOutputs(newDeviation, playerRating, newDeviation, playerVolatility)
+		}
+		return playerRating, newDeviation, playerVolatility, nil
 	} else {
 		variance := calculateVarianceFromGameOutcomes(playerRating, opponentRatings, opponentDeviations)
 
-		newVolatility := calcVolatilityFromMatches(
+		var volatilityTrace *Glicko2VolatilityTrace
+		if trace != nil {
+			trace.Variance = variance
+			trace.Delta = calculateEstimatedRatingImprovement(playerRating, opponentRatings, opponentDeviations, gameOutcomes, variance)
+			volatilityTrace = &trace.VolatilitySolver
+		}
+
+		newVolatility, err := calcVolatilityFromMatches(
 			playerRating, playerDeviation, playerVolatility, variance,
 			opponentRatings, opponentDeviations, gameOutcomes,
-			settings.ConvergenceTolerance, settings.SystemConstant)
+			settings.ConvergenceTolerance, settings.SystemConstant, settings.maxIterations(), volatilityTrace)
+		if err != nil {
+			return -1, -1, -1, err
+		}
+
+		preRatingDeviation := math.Min(calcElapsedPreRatingDeviation(playerDeviation, newVolatility, elapsedPeriods), settings.maxDeviation())
 
-		newDeviation := calcPlayedPeriodDeviation(playerDeviation, variance, newVolatility)
+		newDeviation := calcPlayedPeriodDeviation(preRatingDeviation, variance)
 
 		newRating := calcPlayedPeriodRating(playerRating, newDeviation, opponentRatings, opponentDeviations, gameOutcomes)
 
+		if trace != nil {
+			trace.recordOutputs(preRatingDeviation, newRating, newDeviation, newVolatility)
+		}
+
 		return newRating, newDeviation, newVolatility, nil
 	}
 }
@@ -205,54 +283,27 @@ func UpdatePlayerFromMatches(playerRating float64, playerDeviation float64, play
 // Matches are derived from `opponentRatings`, `opponentDeviations` and `gameOutcomes` using the order of their contents.
 //
 // `settings` can be used to denote the constants used for the application's Glicko environment.
+// Equivalent to Rater.UpdatePlayerRaw.
 func RawPlayerUpdaterWithSettings(settings Glicko2AlgorithmSettings) func(playerRating float64, playerDeviation float64, playerVolatility float64,
 	opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64) (Glicko2Player, error) {
-
-	return func(playerRating float64, playerDeviation float64, playerVolatility float64, opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64) (Glicko2Player, error) {
-
-		newRating, newDeviation, newVolatility, err := UpdatePlayerFromMatches(playerRating, playerDeviation, playerVolatility, opponentRatings, opponentDeviations, gameOutcomes, settings)
-
-		if err != nil {
-			return Glicko2Player{}, err
-		}
-
-		return Glicko2Player{
-			GlickoPlayer: GlickoPlayer{
-				Rating:          newRating,
-				RatingDeviation: newDeviation,
-			},
-			RatingVolatility: newVolatility,
-		}, nil
-	}
+	return NewRater(settings).UpdatePlayerRaw
 }
 
 // RawPlayerUpdaterWithDefaultSettings returns a RawPlayerUpdaterWithSettings function with the following settings:
 //   - System constant: GLICKO2_DEFAULT_SYSTEM_CONSTANT
 //   - Convergence tolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE
+//   - Max iterations: GLICKO2_DEFAULT_MAX_ITERATIONS
+//   - Max deviation: GLICKO2_DEFAULT_MAX_DEVIATION
 func RawPlayerUpdaterWithDefaultSettings() func(playerRating float64, playerDeviation float64, playerVolatility float64,
 	opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64) (Glicko2Player, error) {
 	return RawPlayerUpdaterWithSettings(glicko2DefaultSettings)
 }
 
 // PlayerUpdaterWithSettings returns a wrapper of RawPlayerUpdaterWithSettings that allows for a player's period to be calculated
-// via Glicko2Player structures over arrays of each value.
+// via Glicko2Player structures over arrays of each value. Equivalent to Rater.UpdatePlayerAgainst.
 func PlayerUpdaterWithSettings(settings Glicko2AlgorithmSettings) func(player Glicko2Player,
 	opponents []Glicko2Player, gameOutcomes []float64) (Glicko2Player, error) {
-
-	return func(player Glicko2Player, opponents []Glicko2Player, gameOutcomes []float64) (Glicko2Player, error) {
-		playerUpdater := RawPlayerUpdaterWithSettings(settings)
-
-		var opponentRatings []float64
-		var opponentDeviations []float64
-
-		for _, opponent := range opponents {
-			opponentRatings = append(opponentRatings, opponent.Rating)
-			opponentDeviations = append(opponentDeviations, opponent.RatingDeviation)
-		}
-
-		return playerUpdater(player.Rating, player.RatingDeviation, player.RatingVolatility, opponentRatings, opponentDeviations, gameOutcomes)
-	}
-
+	return NewRater(settings).UpdatePlayerAgainst
 }
 
 // PlayerUpdaterWithDefaultSettings provides default settings for PlayerUpdaterWithSettings,
@@ -262,22 +313,8 @@ func PlayerUpdaterWithDefaultSettings() func(player Glicko2Player,
 	return PlayerUpdaterWithSettings(glicko2DefaultSettings)
 }
 
+// NewPlayerUpdaterWithSettings returns a function used to update a player from a list of Glicko2MatchForPlayer.
+// Equivalent to Rater.UpdatePlayer.
 func NewPlayerUpdaterWithSettings(settings Glicko2AlgorithmSettings) func(player Glicko2Player, periodGames []Glicko2MatchForPlayer) (Glicko2Player, error) {
-
-	return func(player Glicko2Player, periodGames []Glicko2MatchForPlayer) (Glicko2Player, error) {
-		playerUpdater := RawPlayerUpdaterWithSettings(settings)
-
-		var opponentRatings []float64
-		var opponentDeviations []float64
-		// TODO: Be more consistent with usage of game result vs outcome
-		var gameResults []float64
-
-		for _, game := range periodGames {
-			opponentRatings = append(opponentRatings, game.Opponent.Rating)
-			opponentDeviations = append(opponentDeviations, game.Opponent.RatingDeviation)
-			gameResults = append(gameResults, game.Result)
-		}
-
-		return playerUpdater(player.Rating, player.RatingDeviation, player.RatingVolatility, opponentRatings, opponentDeviations, gameResults)
-	}
+	return NewRater(settings).UpdatePlayer
 }
@@ -1,6 +1,10 @@
 package glicko2go
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"testing"
 )
 
@@ -35,7 +39,8 @@ func calculateExampleWithoutPlayerStructs() (float64, float64, float64, error) {
 	)
 }
 
-func calculateExampleWithPlayerStructs() (Glicko2Player, error) {
+// calculateExampleWithPlayerStructsInputs returns the example's player and opponents as Glicko2Player structs.
+func calculateExampleWithPlayerStructsInputs() (Glicko2Player, []Glicko2Player) {
 	playerToUpdate := ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{
 		Rating:          playerRating,
 		RatingDeviation: playerDeviation,
@@ -50,6 +55,12 @@ func calculateExampleWithPlayerStructs() (Glicko2Player, error) {
 		}))
 	}
 
+	return playerToUpdate, opponents
+}
+
+func calculateExampleWithPlayerStructs() (Glicko2Player, error) {
+	playerToUpdate, opponents := calculateExampleWithPlayerStructsInputs()
+
 	playerUpdater := PlayerUpdaterWithDefaultSettings()
 
 	return playerUpdater(playerToUpdate, opponents, gameOutcomes)
@@ -79,3 +90,220 @@ func TestGlicko2CalculationMethodsMatch(t *testing.T) {
 	}
 
 }
+
+func TestSettingsValidation(t *testing.T) {
+	invalidSettings := []Glicko2AlgorithmSettings{
+		{SystemConstant: 0, ConvergenceTolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE},
+		{SystemConstant: -0.5, ConvergenceTolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE},
+		{SystemConstant: GLICKO2_DEFAULT_SYSTEM_CONSTANT, ConvergenceTolerance: 0},
+		{SystemConstant: GLICKO2_DEFAULT_SYSTEM_CONSTANT, ConvergenceTolerance: math.NaN()},
+		{SystemConstant: GLICKO2_DEFAULT_SYSTEM_CONSTANT, ConvergenceTolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE, MaxIterations: -1},
+	}
+
+	for _, settings := range invalidSettings {
+		if err := settings.Validate(); !errors.Is(err, ErrInvalidSettings) {
+			t.Errorf("Settings %+v were not rejected, got: %v", settings, err)
+		}
+
+		_, err := PlayerUpdaterWithSettings(settings)(NewDefaultGlicko2Player(), []Glicko2Player{NewDefaultGlicko2Player()}, []float64{GAME_OUTCOME_WIN})
+		if !errors.Is(err, ErrInvalidSettings) {
+			t.Errorf("Updating with settings %+v did not return ErrInvalidSettings, got: %v", settings, err)
+		}
+	}
+
+	if err := glicko2DefaultSettings.Validate(); err != nil {
+		t.Errorf("Default settings are invalid: %v", err)
+	}
+}
+
+func TestVolatilityDidNotConverge(t *testing.T) {
+	settings := glicko2DefaultSettings
+	settings.MaxIterations = 1
+
+	player, opponents := calculateExampleWithPlayerStructsInputs()
+	_, err := PlayerUpdaterWithSettings(settings)(player, opponents, gameOutcomes)
+	if !errors.Is(err, ErrVolatilityDidNotConverge) {
+		t.Errorf("Expected ErrVolatilityDidNotConverge, got: %v", err)
+	}
+}
+
+func TestRaterMatchesUpdaterFunctions(t *testing.T) {
+	var ratingSystem RatingSystem = NewDefaultRater()
+
+	if ratingSystem.Settings() != glicko2DefaultSettings {
+		t.Errorf("Default rater does not use default settings: %+v", ratingSystem.Settings())
+	}
+
+	player, opponents := calculateExampleWithPlayerStructsInputs()
+	var periodGames []Glicko2MatchForPlayer
+	for i, opponent := range opponents {
+		periodGames = append(periodGames, Glicko2MatchForPlayer{Opponent: opponent, Result: gameOutcomes[i]})
+	}
+
+	raterPlayer, err := ratingSystem.UpdatePlayer(player, periodGames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updaterPlayer, err := calculateExampleWithPlayerStructs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if raterPlayer != updaterPlayer {
+		t.Errorf("Rater and updater functions do not match: \nRater:   %v\nUpdater: %v", raterPlayer, updaterPlayer)
+	}
+}
+
+func TestWinProbability(t *testing.T) {
+	// Example from the Glicko paper's discussion of expected game outcomes. See: https://www.glicko.net/glicko/glicko.pdf
+	player := GlickoPlayer{Rating: 1400, RatingDeviation: 80}
+	opponent := GlickoPlayer{Rating: 1500, RatingDeviation: 150}
+
+	if probability := player.WinProbability(opponent); math.Abs(probability-0.376) > 0.0005 {
+		t.Errorf("Expected a win probability of 0.376, got: %v", probability)
+	}
+
+	g2Player, g2Opponent := ConvertToGlicko2WithDefaultVolatility(player), ConvertToGlicko2WithDefaultVolatility(opponent)
+	if sum := g2Player.WinProbability(g2Opponent) + g2Opponent.WinProbability(g2Player); math.Abs(sum-1) > 1e-12 {
+		t.Errorf("Win probabilities of both players do not sum to 1: %v", sum)
+	}
+
+	if probability := NewDefaultGlicko2Player().WinProbability(NewDefaultGlicko2Player()); probability != 0.5 {
+		t.Errorf("Identical players do not have a win probability of 0.5: %v", probability)
+	}
+
+	uncertainPlayer := g2Player
+	uncertainPlayer.RatingDeviation *= 3
+	if g2Player.WinProbability(g2Opponent) >= uncertainPlayer.WinProbability(g2Opponent) {
+		t.Errorf("A higher deviation for the player does not move the win probability towards 0.5")
+	}
+}
+
+func TestPredictionLogLoss(t *testing.T) {
+	if logLoss := PredictionLogLoss(0.5, GAME_OUTCOME_WIN); math.Abs(logLoss-math.Ln2) > 1e-15 {
+		t.Errorf("Expected a log-loss of ln 2 for predicting 0.5, got: %v", logLoss)
+	}
+	if win, loss := PredictionLogLoss(0.8, GAME_OUTCOME_WIN), PredictionLogLoss(0.2, GAME_OUTCOME_LOSS); win != loss {
+		t.Errorf("Mirrored predictions have different log-losses: %v and %v", win, loss)
+	}
+	if logLoss := PredictionLogLoss(0, GAME_OUTCOME_WIN); math.IsInf(logLoss, 0) || logLoss != -math.Log(GLICKO2_PROBABILITY_EPSILON) {
+		t.Errorf("A certain but wrong prediction is not clamped: %v", logLoss)
+	}
+}
+
+// TestMatchPreviewMatchesUpdate ensures that previewing the example's final match gives the same result as playing it.
+func TestMatchPreviewMatchesUpdate(t *testing.T) {
+	player, opponents := calculateExampleWithPlayerStructsInputs()
+	lastOpponent := len(opponents) - 1
+
+	var periodGames []Glicko2MatchForPlayer
+	for i := 0; i < lastOpponent; i++ {
+		periodGames = append(periodGames, Glicko2MatchForPlayer{Opponent: opponents[i], Result: gameOutcomes[i]})
+	}
+
+	preview, err := MatchPreviewerWithDefaultSettings()(player, opponents[lastOpponent], periodGames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updatedPlayer, err := calculateExampleWithPlayerStructs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The example's final match is a loss
+	if preview.Loss != updatedPlayer {
+		t.Errorf("Previewed loss does not match the update: \nPreview: %v\nUpdate:  %v", preview.Loss, updatedPlayer)
+	}
+	if !(preview.Win.Rating > preview.Draw.Rating && preview.Draw.Rating > preview.Loss.Rating) {
+		t.Errorf("Previews are not ordered by outcome: %+v", preview)
+	}
+	if len(periodGames) != lastOpponent {
+		t.Errorf("Previewing modified the period's games: %v", periodGames)
+	}
+}
+
+// TestCalculationTrace compares a trace of the example against the intermediate values given within the paper.
+// The paper rounds intermediate values before using them, so each is compared with a tolerance for that rounding.
+func TestCalculationTrace(t *testing.T) {
+	var g2Ratings []float64
+	var g2Deviations []float64
+
+	for i := 0; i < len(opponentDeviations); i++ {
+		g2Ratings = append(g2Ratings, GlickoRatingToGlicko2(opponentRatings[i]))
+		g2Deviations = append(g2Deviations, GlickoDeviationToGlicko2(opponentDeviations[i]))
+	}
+
+	trace, err := UpdatePlayerFromMatchesWithTrace(
+		GlickoRatingToGlicko2(playerRating), GlickoDeviationToGlicko2(playerDeviation), GLICKO2_DEFAULT_PLAYER_VOLATILITY,
+		g2Ratings, g2Deviations, gameOutcomes, glicko2DefaultSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectValue := func(name string, got float64, expected float64, tolerance float64) {
+		if math.Abs(got-expected) > tolerance {
+			t.Errorf("%v does not match the paper: expected %v, got %v", name, expected, got)
+		}
+	}
+
+	expectedG := []float64{0.9955, 0.9531, 0.7242}
+	expectedE := []float64{0.639, 0.432, 0.303}
+	for i, game := range trace.Games {
+		expectValue(fmt.Sprintf("g(φ%v)", i+1), game.G, expectedG[i], 0.0001)
+		expectValue(fmt.Sprintf("E%v", i+1), game.E, expectedE[i], 0.001)
+	}
+	expectValue("v", trace.Variance, 1.7785, 0.001)
+	expectValue("∆", trace.Delta, -0.4834, 0.001)
+	expectValue("σ′", trace.NewVolatility, 0.05999, 0.00001)
+	expectValue("φ*", trace.PreRatingDeviation, 1.152862, 0.00001)
+	expectValue("φ′", trace.NewDeviation, 0.8722, 0.0001)
+	expectValue("µ′", trace.NewRating, -0.2069, 0.0001)
+
+	if len(trace.VolatilitySolver.Iterations) == 0 {
+		t.Errorf("No volatility solver iterations were recorded")
+	}
+
+	dRating, dDeviation, dVolatility, err := calculateExampleWithoutPlayerStructs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trace.NewRating != dRating || trace.NewDeviation != dDeviation || trace.NewVolatility != dVolatility {
+		t.Errorf("Trace results do not match UpdatePlayerFromMatches: \nTrace: %v, %v, %v\nUpdate: %v, %v, %v",
+			trace.NewRating, trace.NewDeviation, trace.NewVolatility, dRating, dDeviation, dVolatility)
+	}
+
+	encodedTrace, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatalf("Trace cannot be serialised: %v", err)
+	}
+	var decodedTrace Glicko2CalculationTrace
+	if err := json.Unmarshal(encodedTrace, &decodedTrace); err != nil {
+		t.Fatalf("Trace cannot be deserialised: %v", err)
+	}
+	if decodedTrace.NewRating != trace.NewRating || len(decodedTrace.VolatilitySolver.Iterations) != len(trace.VolatilitySolver.Iterations) {
+		t.Errorf("Trace changes after serialisation: \nBefore: %+v\nAfter:  %+v", trace, decodedTrace)
+	}
+}
+
+func TestScaleConversions(t *testing.T) {
+	standardPlayer := GlickoPlayer{Rating: 1700, RatingDeviation: 120}
+	if standardScale := NewStandardScale(); standardScale.ConvertToGlicko2WithDefaultVolatility(standardPlayer) != ConvertToGlicko2WithDefaultVolatility(standardPlayer) {
+		t.Errorf("Standard scale does not match the package's conversions")
+	}
+
+	customScale := NewScale(1000, 100)
+	if rating := customScale.RatingToGlicko2(1000); rating != 0 {
+		t.Errorf("Centre of custom scale is not converted to 0: %v", rating)
+	}
+	if rating := customScale.Glicko2RatingToScale(customScale.RatingToGlicko2(1234.5)); math.Abs(rating-1234.5) > 1e-9 {
+		t.Errorf("Rating does not survive a round trip through the custom scale: %v", rating)
+	}
+
+	customDefault := customScale.NewDefaultGlicko2Player()
+	standardDefault := NewDefaultGlicko2Player()
+	if customDefault.Rating != standardDefault.Rating ||
+		math.Abs(customDefault.RatingDeviation-standardDefault.RatingDeviation) > 1e-12 ||
+		customDefault.RatingVolatility != standardDefault.RatingVolatility {
+		t.Errorf("Default players differ between scales on the Glicko 2 scale: \nCustom:   %v\nStandard: %v", customDefault, standardDefault)
+	}
+}
@@ -0,0 +1,69 @@
+package glicko2go
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGlickoExample compares the example at https://www.glicko.net/glicko/glicko.pdf against the Glicko updater.
+// The example's deviation has already been through step 1, so no growth constant is used.
+func TestGlickoExample(t *testing.T) {
+	settings := GlickoAlgorithmSettings{DeviationGrowthConstant: 0}
+
+	newRating, newDeviation, err := UpdateGlickoPlayerFromMatches(playerRating, playerDeviation, opponentRatings, opponentDeviations, gameOutcomes, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(newRating-1464) > 0.5 {
+		t.Errorf("Rating does not match the paper: expected 1464, got %v", newRating)
+	}
+	if math.Abs(newDeviation-151.4) > 0.05 {
+		t.Errorf("Deviation does not match the paper: expected 151.4, got %v", newDeviation)
+	}
+	// The paper rounds intermediate values before using them, so d is compared with a tolerance for that rounding
+	if d := math.Sqrt(glickoDSquared(playerRating, opponentRatings, opponentDeviations)); math.Abs(d-231.67) > 0.05 {
+		t.Errorf("d does not match the paper: expected 231.67, got %v", d)
+	}
+}
+
+// TestGlickoPeriodCalculator ensures that the Glicko period calculator matches the Glicko updater,
+// and that inactive players' deviations grow by the deviation growth constant up to the maximum deviation.
+func TestGlickoPeriodCalculator(t *testing.T) {
+	glicko2Players, matchList := getExamplePlayersAndMatches()
+
+	players := make(map[int]GlickoPlayer)
+	for id, player := range glicko2Players {
+		players[id] = ConvertToGlicko(player)
+	}
+	players[5] = GlickoPlayer{Rating: 1500, RatingDeviation: 50}
+	players[6] = GlickoPlayer{Rating: 1500, RatingDeviation: 349}
+
+	postPeriodPlayers, err := DefaultGlickoPeriodCalculator()(players, matchList)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var periodGames []GlickoMatchForPlayer
+	for i := range opponentRatings {
+		periodGames = append(periodGames, GlickoMatchForPlayer{
+			Opponent: players[i+2],
+			Result:   gameOutcomes[i],
+		})
+	}
+	updatedPlayer, err := GlickoPlayerUpdaterWithDefaultSettings()(players[1], periodGames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if postPeriodPlayers[1] != updatedPlayer {
+		t.Errorf("Period calculator does not match the player updater: \nPeriod: %v\nUpdater: %v", postPeriodPlayers[1], updatedPlayer)
+	}
+
+	expectedDeviation := math.Sqrt(math.Pow(50, 2) + math.Pow(GLICKO_DEFAULT_DEVIATION_GROWTH_CONSTANT, 2))
+	if postPeriodPlayers[5].Rating != 1500 || postPeriodPlayers[5].RatingDeviation != expectedDeviation {
+		t.Errorf("Inactive player is not updated by step 1 alone: expected {1500 %v}, got %v", expectedDeviation, postPeriodPlayers[5])
+	}
+	if postPeriodPlayers[6].RatingDeviation != GLICKO_DEFAULT_PLAYER_DEVIATION {
+		t.Errorf("Inactive player's deviation is not capped: %v", postPeriodPlayers[6].RatingDeviation)
+	}
+}
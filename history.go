@@ -0,0 +1,171 @@
+package glicko2go
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RatingHistoryPeriod holds every player's stats at the end of a single period, covering [Start, End).
+type RatingHistoryPeriod[ID comparable] struct {
+	// Index is the position of the period within its RatingHistory, starting from 0.
+	Index int `json:"index"`
+	// Start may be left as the zero time if it is unknown.
+	Start   time.Time            `json:"start,omitzero"`
+	End     time.Time            `json:"end"`
+	Players map[ID]Glicko2Player `json:"players"`
+}
+
+// RatingSnapshot is a single player's stats at the end of a period.
+type RatingSnapshot struct {
+	Period int           `json:"period"`
+	End    time.Time     `json:"end"`
+	Player Glicko2Player `json:"player"`
+}
+
+// RatingHistory records the output of every period, so each player's stats can be queried across periods.
+// It is safe for concurrent use.
+//
+// RatingHistory can be encoded as JSON, which requires `ID` to be usable as a JSON object key
+// (a string or integer type, or a type implementing encoding.TextMarshaler).
+type RatingHistory[ID comparable] struct {
+	mu      sync.RWMutex
+	periods []RatingHistoryPeriod[ID]
+}
+
+// NewRatingHistory creates an empty RatingHistory.
+func NewRatingHistory[ID comparable]() *RatingHistory[ID] {
+	return &RatingHistory[ID]{}
+}
+
+// Record stores a copy of `players` as the stats at the end of the period covering [start, end), returning its index.
+// Periods must be recorded in order, so an error is returned if `end` is not after the end of the previous period.
+// `start` may be the zero time if it is unknown.
+func (h *RatingHistory[ID]) Record(start time.Time, end time.Time, players map[ID]Glicko2Player) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !start.IsZero() && !end.After(start) {
+		return 0, fmt.Errorf("period must end after it starts, got a period from %v to %v", start, end)
+	}
+	if len(h.periods) > 0 && !end.After(h.periods[len(h.periods)-1].End) {
+		return 0, fmt.Errorf("period ending at %v must end after the previous period, which ended at %v", end, h.periods[len(h.periods)-1].End)
+	}
+
+	index := len(h.periods)
+	h.periods = append(h.periods, RatingHistoryPeriod[ID]{
+		Index:   index,
+		Start:   start,
+		End:     end,
+		Players: maps.Clone(players),
+	})
+	return index, nil
+}
+
+// Len returns the number of periods recorded.
+func (h *RatingHistory[ID]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.periods)
+}
+
+// Period returns the period at `index`, and false if it has not been recorded.
+func (h *RatingHistory[ID]) Period(index int) (RatingHistoryPeriod[ID], bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if index < 0 || index >= len(h.periods) {
+		return RatingHistoryPeriod[ID]{}, false
+	}
+	period := h.periods[index]
+	period.Players = maps.Clone(period.Players)
+	return period, true
+}
+
+// Snapshots returns the player's stats at the end of every period they were recorded in, in order.
+func (h *RatingHistory[ID]) Snapshots(id ID) []RatingSnapshot {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var snapshots []RatingSnapshot
+	for _, period := range h.periods {
+		if player, ok := period.Players[id]; ok {
+			snapshots = append(snapshots, RatingSnapshot{Period: period.Index, End: period.End, Player: player})
+		}
+	}
+	return snapshots
+}
+
+// PlayerAt returns the player's stats as of time `t`, which are from the latest period they were recorded in that ended
+// at or before `t`. False is returned if no such period exists.
+func (h *RatingHistory[ID]) PlayerAt(id ID, t time.Time) (Glicko2Player, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	// Index of the first period ending after `t`
+	endedBefore := sort.Search(len(h.periods), func(i int) bool {
+		return h.periods[i].End.After(t)
+	})
+
+	for i := endedBefore - 1; i >= 0; i-- {
+		if player, ok := h.periods[i].Players[id]; ok {
+			return player, true
+		}
+	}
+	return Glicko2Player{}, false
+}
+
+// Peak returns the snapshot with the player's highest rating, and false if they have not been recorded.
+// If several periods share the highest rating, the earliest is returned.
+func (h *RatingHistory[ID]) Peak(id ID) (RatingSnapshot, bool) {
+	var peak RatingSnapshot
+	found := false
+
+	for _, snapshot := range h.Snapshots(id) {
+		if !found || snapshot.Player.Rating > peak.Player.Rating {
+			peak = snapshot
+			found = true
+		}
+	}
+	return peak, found
+}
+
+// ratingHistoryJSON is the encoded form of a RatingHistory.
+type ratingHistoryJSON[ID comparable] struct {
+	Periods []RatingHistoryPeriod[ID] `json:"periods"`
+}
+
+func (h *RatingHistory[ID]) MarshalJSON() ([]byte, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	periods := h.periods
+	if periods == nil {
+		periods = []RatingHistoryPeriod[ID]{}
+	}
+	return json.Marshal(ratingHistoryJSON[ID]{Periods: periods})
+}
+
+// UnmarshalJSON replaces the recorded periods with those in `data`. Periods are re-indexed in the order given,
+// and must be in order as with RatingHistory.Record.
+func (h *RatingHistory[ID]) UnmarshalJSON(data []byte) error {
+	var decoded ratingHistoryJSON[ID]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	history := NewRatingHistory[ID]()
+	for _, period := range decoded.Periods {
+		if _, err := history.Record(period.Start, period.End, period.Players); err != nil {
+			return err
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.periods = history.periods
+	return nil
+}
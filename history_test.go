@@ -0,0 +1,102 @@
+package glicko2go
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestScheduledPeriodCalculatorWithHistory plays the example on the first and third days, recording every day.
+func TestScheduledPeriodCalculatorWithHistory(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	var scheduledMatches []Glicko2MatchByID
+	for _, day := range []int{0, 2} {
+		for _, match := range matchList {
+			match.PlayedAt = start.AddDate(0, 0, day)
+			scheduledMatches = append(scheduledMatches, match)
+		}
+	}
+
+	history := NewRatingHistory[int]()
+	scheduledCalculator := ScheduledPeriodCalculatorWithHistory(glicko2DefaultSettings, DailyPeriodSchedule(start), history)
+	finalPlayers, _, err := scheduledCalculator(players, scheduledMatches, start.AddDate(0, 0, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots := history.Snapshots(1)
+	if len(snapshots) != 3 {
+		t.Fatalf("Expected 3 snapshots of player 1, got %v", len(snapshots))
+	}
+	if snapshots[2].Player != finalPlayers[1] {
+		t.Errorf("The final snapshot %+v does not match the final player %+v", snapshots[2].Player, finalPlayers[1])
+	}
+
+	// During the third day, the player's stats are those at the end of the second day
+	if player, ok := history.PlayerAt(1, start.AddDate(0, 0, 2).Add(time.Hour)); !ok || player != snapshots[1].Player {
+		t.Errorf("Expected %+v during the third day, got %+v", snapshots[1].Player, player)
+	}
+	if _, ok := history.PlayerAt(1, start.Add(time.Hour)); ok {
+		t.Errorf("Player 1 should have no stats before the first period ends")
+	}
+	if _, ok := history.PlayerAt(5, start.AddDate(0, 0, 3)); ok {
+		t.Errorf("Player 5 was never recorded, but had stats")
+	}
+
+	// Player 4 wins both days, so peaks at the end
+	if peak, ok := history.Peak(4); !ok || peak.Period != 2 {
+		t.Errorf("Expected player 4 to peak in period 2, got %+v", peak)
+	}
+}
+
+func TestRatingHistoryRejectsOutOfOrderPeriods(t *testing.T) {
+	history := NewRatingHistory[int]()
+	end := time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC)
+
+	if _, err := history.Record(time.Time{}, end, getExamplePlayers()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := history.Record(time.Time{}, end.Add(-time.Hour), getExamplePlayers()); err == nil {
+		t.Errorf("A period ending before the previous period did not cause an error")
+	}
+	if _, err := history.Record(end.AddDate(0, 0, 2), end.AddDate(0, 0, 1), getExamplePlayers()); err == nil {
+		t.Errorf("A period ending before it starts did not cause an error")
+	}
+	if history.Len() != 1 {
+		t.Errorf("Invalid periods were recorded")
+	}
+}
+
+func TestRatingHistoryJSON(t *testing.T) {
+	history := NewRatingHistory[string]()
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for day := range 3 {
+		players := map[string]Glicko2Player{"alice": NewDefaultGlicko2Player()}
+		players["alice"] = players["alice"].AfterInactivity(float64(day))
+		if _, err := history.Record(start.AddDate(0, 0, day), start.AddDate(0, 0, day+1), players); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := NewRatingHistory[string]()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, decodedSnapshots := history.Snapshots("alice"), decoded.Snapshots("alice")
+	if len(decodedSnapshots) != len(expected) {
+		t.Fatalf("Expected %v snapshots after decoding, got %v", len(expected), len(decodedSnapshots))
+	}
+	for i := range expected {
+		if decodedSnapshots[i].Player != expected[i].Player || !decodedSnapshots[i].End.Equal(expected[i].End) {
+			t.Errorf("Snapshot %v was %+v after decoding, expected %+v", i, decodedSnapshots[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,323 @@
+// Package glicko2io streams matches and player snapshots into glicko2go types from CSV and JSON Lines.
+// Readers never hold more than a single record in memory, and every error reports the line it was found on.
+package glicko2io
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Too-Zestyy/glicko2go"
+)
+
+// ParseError is returned when a record cannot be read, recording the line it was found on.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %v: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrInvalidOptions is wrapped by every error returned from CSVMatchOptions.Validate and PlayerOptions.Validate.
+var ErrInvalidOptions = errors.New("invalid CSV options")
+
+// MatchReader is implemented by CSVMatchReader and JSONLMatchReader.
+type MatchReader interface {
+	// Read returns the next match, or io.EOF once every match has been read.
+	Read() (glicko2go.Glicko2MatchByID, error)
+}
+
+// ReadAllMatches reads every remaining match from `reader`.
+// Prefer calling Read directly when the matches do not all need to be held in memory at once.
+func ReadAllMatches(reader MatchReader) ([]glicko2go.Glicko2MatchByID, error) {
+	var matches []glicko2go.Glicko2MatchByID
+	for {
+		match, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return matches, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+}
+
+// MatchColumns maps the fields of a match to zero-based CSV column indexes. A negative PlayedAt means that matches have no timestamp,
+// and records that end before the PlayedAt column are read with the zero time.
+type MatchColumns struct {
+	Player1ID int
+	Player2ID int
+	Result    int
+	PlayedAt  int
+}
+
+// DefaultMatchColumns reads matches as `player1,player2,result[,timestamp]`.
+var DefaultMatchColumns = MatchColumns{Player1ID: 0, Player2ID: 1, Result: 2, PlayedAt: 3}
+
+// MatchColumnNames maps the fields of a match to CSV header names. An empty PlayedAt means that matches have no timestamp.
+type MatchColumnNames struct {
+	Player1ID string
+	Player2ID string
+	Result    string
+	PlayedAt  string
+}
+
+// CSVMatchOptions configures a CSVMatchReader.
+type CSVMatchOptions struct {
+	// Comma is the field delimiter. If 0, ',' is used.
+	Comma rune
+	// HasHeader skips the first record. It is implied if ColumnNames is set.
+	HasHeader bool
+	// Columns is used to find each field, unless ColumnNames is set.
+	Columns MatchColumns
+	// ColumnNames finds each field's column from the header, taking priority over Columns.
+	ColumnNames *MatchColumnNames
+	// TimeLayout is used to parse timestamps, as with time.Parse. If empty, time.RFC3339 is used.
+	// Empty timestamps are read as the zero time.
+	TimeLayout string
+}
+
+// DefaultCSVMatchOptions reads headerless `player1,player2,result[,timestamp]` records with optional RFC 3339 timestamps.
+func DefaultCSVMatchOptions() CSVMatchOptions {
+	return CSVMatchOptions{
+		Columns:    DefaultMatchColumns,
+		TimeLayout: time.RFC3339,
+	}
+}
+
+// Validate returns an error wrapping ErrInvalidOptions if two fields share a column,
+// such as in a zero-valued CSVMatchOptions, or if a required column is negative or unnamed.
+func (options CSVMatchOptions) Validate() error {
+	if options.ColumnNames != nil {
+		names := *options.ColumnNames
+		if names.Player1ID == "" || names.Player2ID == "" || names.Result == "" {
+			return fmt.Errorf("%w: player 1 ID, player 2 ID and result column names must be set", ErrInvalidOptions)
+		}
+		if names.Player1ID == names.Player2ID || names.Player1ID == names.Result || names.Player2ID == names.Result ||
+			(names.PlayedAt != "" && (names.PlayedAt == names.Player1ID || names.PlayedAt == names.Player2ID || names.PlayedAt == names.Result)) {
+			return fmt.Errorf("%w: each field must use a different column name, got %+v", ErrInvalidOptions, names)
+		}
+		return nil
+	}
+
+	columns := options.Columns
+	if columns.Player1ID < 0 || columns.Player2ID < 0 || columns.Result < 0 {
+		return fmt.Errorf("%w: player 1 ID, player 2 ID and result columns must be non-negative, got %+v", ErrInvalidOptions, columns)
+	}
+	if columns.Player1ID == columns.Player2ID || columns.Player1ID == columns.Result || columns.Player2ID == columns.Result ||
+		(columns.PlayedAt >= 0 && (columns.PlayedAt == columns.Player1ID || columns.PlayedAt == columns.Player2ID || columns.PlayedAt == columns.Result)) {
+		return fmt.Errorf("%w: each field must use a different column, got %+v", ErrInvalidOptions, columns)
+	}
+	return nil
+}
+
+// CSVMatchReader streams Glicko2MatchByID values from CSV records.
+type CSVMatchReader struct {
+	reader        *csv.Reader
+	options       CSVMatchOptions
+	columns       MatchColumns
+	readHeader    bool
+	headerSkipped bool
+	// optionsErr is returned by every call to Read if the options are invalid.
+	optionsErr error
+}
+
+// NewCSVMatchReader creates a CSVMatchReader reading from `r`.
+// If `options` fail CSVMatchOptions.Validate, every call to Read returns that error.
+func NewCSVMatchReader(r io.Reader, options CSVMatchOptions) *CSVMatchReader {
+	reader := csv.NewReader(r)
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	reader.TrimLeadingSpace = true
+
+	if options.TimeLayout == "" {
+		options.TimeLayout = time.RFC3339
+	}
+
+	return &CSVMatchReader{
+		reader:     reader,
+		options:    options,
+		columns:    options.Columns,
+		readHeader: options.HasHeader || options.ColumnNames != nil,
+		optionsErr: options.Validate(),
+	}
+}
+
+// Read returns the next match, or io.EOF once every match has been read.
+func (m *CSVMatchReader) Read() (glicko2go.Glicko2MatchByID, error) {
+	if m.optionsErr != nil {
+		return glicko2go.Glicko2MatchByID{}, m.optionsErr
+	}
+	if m.readHeader && !m.headerSkipped {
+		header, err := m.readRecord()
+		if err != nil {
+			return glicko2go.Glicko2MatchByID{}, err
+		}
+		m.headerSkipped = true
+
+		if m.options.ColumnNames != nil {
+			if m.columns, err = resolveMatchColumns(header, *m.options.ColumnNames); err != nil {
+				return glicko2go.Glicko2MatchByID{}, &ParseError{Line: m.line(), Err: err}
+			}
+		}
+	}
+
+	record, err := m.readRecord()
+	if err != nil {
+		return glicko2go.Glicko2MatchByID{}, err
+	}
+
+	match, err := m.parseRecord(record)
+	if err != nil {
+		return glicko2go.Glicko2MatchByID{}, &ParseError{Line: m.line(), Err: err}
+	}
+	return match, nil
+}
+
+// readRecord reads the next CSV record, converting CSV syntax errors into a *ParseError.
+func (m *CSVMatchReader) readRecord() ([]string, error) {
+	record, err := m.reader.Read()
+	if err != nil {
+		var csvErr *csv.ParseError
+		if errors.As(err, &csvErr) {
+			return nil, &ParseError{Line: csvErr.Line, Err: csvErr.Err}
+		}
+		return nil, err
+	}
+	return record, nil
+}
+
+// line returns the line of the most recently read record.
+func (m *CSVMatchReader) line() int {
+	line, _ := m.reader.FieldPos(0)
+	return line
+}
+
+func (m *CSVMatchReader) parseRecord(record []string) (glicko2go.Glicko2MatchByID, error) {
+	var match glicko2go.Glicko2MatchByID
+	var err error
+
+	if match.Player1ID, err = parseIntField(record, m.columns.Player1ID, "player 1 ID"); err != nil {
+		return match, err
+	}
+	if match.Player2ID, err = parseIntField(record, m.columns.Player2ID, "player 2 ID"); err != nil {
+		return match, err
+	}
+
+	resultField, err := field(record, m.columns.Result, "result")
+	if err != nil {
+		return match, err
+	}
+	if match.Result, err = ParseResult(resultField); err != nil {
+		return match, err
+	}
+
+	if m.columns.PlayedAt >= 0 && m.columns.PlayedAt < len(record) {
+		playedAtField, err := field(record, m.columns.PlayedAt, "timestamp")
+		if err != nil {
+			return match, err
+		}
+		if playedAtField != "" {
+			if match.PlayedAt, err = time.Parse(m.options.TimeLayout, playedAtField); err != nil {
+				return match, fmt.Errorf("invalid timestamp: %w", err)
+			}
+		}
+	}
+
+	return match, nil
+}
+
+// ParseResult parses a match result, either as a number or one of `win`, `draw` and `loss` (ignoring case).
+func ParseResult(value string) (float64, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "win", "w":
+		return glicko2go.GAME_OUTCOME_WIN, nil
+	case "draw", "d":
+		return glicko2go.GAME_OUTCOME_DRAW, nil
+	case "loss", "l":
+		return glicko2go.GAME_OUTCOME_LOSS, nil
+	}
+
+	result, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid result %q", value)
+	}
+	return result, nil
+}
+
+// resolveMatchColumns finds the index of each named column within `header`.
+func resolveMatchColumns(header []string, names MatchColumnNames) (MatchColumns, error) {
+	columns := MatchColumns{PlayedAt: -1}
+	var err error
+
+	if columns.Player1ID, err = columnIndex(header, names.Player1ID); err != nil {
+		return columns, err
+	}
+	if columns.Player2ID, err = columnIndex(header, names.Player2ID); err != nil {
+		return columns, err
+	}
+	if columns.Result, err = columnIndex(header, names.Result); err != nil {
+		return columns, err
+	}
+	if names.PlayedAt != "" {
+		if columns.PlayedAt, err = columnIndex(header, names.PlayedAt); err != nil {
+			return columns, err
+		}
+	}
+
+	return columns, nil
+}
+
+func columnIndex(header []string, name string) (int, error) {
+	for i, column := range header {
+		if strings.TrimSpace(column) == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("header has no %q column", name)
+}
+
+func field(record []string, index int, name string) (string, error) {
+	if index < 0 || index >= len(record) {
+		return "", fmt.Errorf("missing %v column (index %v)", name, index)
+	}
+	return strings.TrimSpace(record[index]), nil
+}
+
+func parseIntField(record []string, index int, name string) (int, error) {
+	value, err := field(record, index, name)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v %q", name, value)
+	}
+	return parsed, nil
+}
+
+func parseFloatField(record []string, index int, name string) (float64, error) {
+	value, err := field(record, index, name)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v %q", name, value)
+	}
+	return parsed, nil
+}
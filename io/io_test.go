@@ -0,0 +1,187 @@
+package glicko2io
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Too-Zestyy/glicko2go"
+)
+
+func TestCSVMatchReader(t *testing.T) {
+	input := "1,2,1,2024-03-01T12:00:00Z\n" +
+		"1,3,loss,2024-03-01T13:00:00Z\n" +
+		"1, 4, 0.5,\n"
+
+	matches, err := ReadAllMatches(NewCSVMatchReader(strings.NewReader(input), DefaultCSVMatchOptions()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []glicko2go.Glicko2MatchByID{
+		{Player1ID: 1, Player2ID: 2, Result: glicko2go.GAME_OUTCOME_WIN, PlayedAt: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)},
+		{Player1ID: 1, Player2ID: 3, Result: glicko2go.GAME_OUTCOME_LOSS, PlayedAt: time.Date(2024, time.March, 1, 13, 0, 0, 0, time.UTC)},
+		{Player1ID: 1, Player2ID: 4, Result: glicko2go.GAME_OUTCOME_DRAW},
+	}
+	if len(matches) != len(expected) {
+		t.Fatalf("Expected %v matches, got %v", len(expected), len(matches))
+	}
+	for i := range expected {
+		if !matches[i].PlayedAt.Equal(expected[i].PlayedAt) || matches[i].Player1ID != expected[i].Player1ID ||
+			matches[i].Player2ID != expected[i].Player2ID || matches[i].Result != expected[i].Result {
+			t.Errorf("Match %v does not match: \nExpected: %v\nGot:      %v", i, expected[i], matches[i])
+		}
+	}
+}
+
+func TestCSVMatchReaderColumnNames(t *testing.T) {
+	input := "result;away;home\n" +
+		"win;7;3\n"
+
+	options := CSVMatchOptions{
+		Comma:       ';',
+		ColumnNames: &MatchColumnNames{Player1ID: "home", Player2ID: "away", Result: "result"},
+	}
+	match, err := NewCSVMatchReader(strings.NewReader(input), options).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if match.Player1ID != 3 || match.Player2ID != 7 || match.Result != glicko2go.GAME_OUTCOME_WIN {
+		t.Errorf("Columns are not mapped by name: %+v", match)
+	}
+}
+
+func TestCSVMatchReaderWithoutTimestamps(t *testing.T) {
+	input := "1,2,win\n" +
+		"2,3,0.5,2024-03-01T12:00:00Z\n"
+
+	matches, err := ReadAllMatches(NewCSVMatchReader(strings.NewReader(input), DefaultCSVMatchOptions()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || !matches[0].PlayedAt.IsZero() || matches[0].Result != glicko2go.GAME_OUTCOME_WIN ||
+		!matches[1].PlayedAt.Equal(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Matches without a timestamp column are not read with the zero time: %+v", matches)
+	}
+}
+
+func TestCSVMatchOptionsValidation(t *testing.T) {
+	invalidOptions := []CSVMatchOptions{
+		{},
+		{Columns: MatchColumns{Player1ID: 0, Player2ID: 1, Result: 2, PlayedAt: 1}},
+		{Columns: MatchColumns{Player1ID: -1, Player2ID: 1, Result: 2, PlayedAt: -1}},
+		{ColumnNames: &MatchColumnNames{Player1ID: "home", Player2ID: "home", Result: "result"}},
+		{ColumnNames: &MatchColumnNames{Player1ID: "home", Player2ID: "away"}},
+	}
+	for _, options := range invalidOptions {
+		_, err := NewCSVMatchReader(strings.NewReader("1,2,1\n"), options).Read()
+		if !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Expected ErrInvalidOptions for %+v, got: %v", options, err)
+		}
+	}
+
+	if err := DefaultCSVMatchOptions().Validate(); err != nil {
+		t.Errorf("Default options are invalid: %v", err)
+	}
+}
+
+func TestParseErrorLineNumbers(t *testing.T) {
+	csvInput := "1,2,1,\n" +
+		"1,2,1,\n" +
+		"1,x,1,\n"
+
+	_, err := ReadAllMatches(NewCSVMatchReader(strings.NewReader(csvInput), DefaultCSVMatchOptions()))
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 3 {
+		t.Errorf("Expected a parse error on line 3, got: %v", err)
+	}
+
+	jsonlInput := `{"player1_id":1,"player2_id":2,"result":1}` + "\n" +
+		"\n" +
+		`{"player1_id":1,"player2_id":2,"result":` + "\n"
+
+	reader := NewJSONLMatchReader(strings.NewReader(jsonlInput))
+	if _, err := reader.Read(); err != nil {
+		t.Fatal(err)
+	}
+	_, err = reader.Read()
+	if !errors.As(err, &parseErr) || parseErr.Line != 3 {
+		t.Errorf("Expected a parse error on line 3, got: %v", err)
+	}
+
+	playerInput := "1,0,2,0.06\n" +
+		"1,0,2,0.06\n"
+	_, err = ReadPlayersCSV(strings.NewReader(playerInput), DefaultPlayerOptions())
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("Expected a duplicate player error on line 2, got: %v", err)
+	}
+}
+
+func TestJSONLMatchReader(t *testing.T) {
+	input := `{"player1_id":1,"player2_id":2,"result":1,"played_at":"2024-03-01T12:00:00Z"}` + "\n" +
+		`{"player1_id":2,"player2_id":3,"result":0.5}` + "\n"
+
+	reader := NewJSONLMatchReader(strings.NewReader(input))
+	first, err := reader.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Player1ID != 1 || first.Player2ID != 2 || first.Result != 1 || !first.PlayedAt.Equal(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected first match: %+v", first)
+	}
+	if _, err := reader.Read(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.Read(); err != io.EOF {
+		t.Errorf("Expected io.EOF after the final match, got: %v", err)
+	}
+}
+
+func TestPlayerOptionsValidation(t *testing.T) {
+	invalidOptions := []PlayerOptions{
+		{},
+		{Columns: PlayerColumns{ID: 0, Rating: 1, Deviation: 2, Volatility: 1}},
+		{Columns: PlayerColumns{ID: 0, Rating: -1, Deviation: 2, Volatility: 3}},
+	}
+	for _, options := range invalidOptions {
+		if _, err := ReadPlayersCSV(strings.NewReader("1,0,2,0.06\n"), options); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Expected ErrInvalidOptions for %+v, got: %v", options, err)
+		}
+	}
+
+	if err := DefaultPlayerOptions().Validate(); err != nil {
+		t.Errorf("Default options are invalid: %v", err)
+	}
+}
+
+func TestReadPlayers(t *testing.T) {
+	standardScale := glicko2go.NewStandardScale()
+
+	csvPlayers, err := ReadPlayersCSV(strings.NewReader("id,rating,deviation\n1,1500,350\n2,1700,100\n"), PlayerOptions{
+		HasHeader: true,
+		Columns:   PlayerColumns{ID: 0, Rating: 1, Deviation: 2, Volatility: -1},
+		Scale:     &standardScale,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if csvPlayers[1] != glicko2go.NewDefaultGlicko2Player() {
+		t.Errorf("Default player is not read from the Glicko scale: %v", csvPlayers[1])
+	}
+
+	jsonlPlayers, err := ReadPlayersJSONL(strings.NewReader(
+		`{"id":1,"rating":0,"rating_deviation":2.014761872416068,"rating_volatility":0.06}`+"\n"+
+			`{"id":2,"rating":1.1512924985234674,"rating_deviation":0.5756462492617337}`+"\n"), DefaultPlayerOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsonlPlayers[1] != glicko2go.NewDefaultGlicko2Player() {
+		t.Errorf("Default player is not read from JSON Lines: %v", jsonlPlayers[1])
+	}
+	if math.Abs(jsonlPlayers[2].Rating-csvPlayers[2].Rating) > 1e-12 || jsonlPlayers[2].RatingVolatility != glicko2go.GLICKO2_DEFAULT_PLAYER_VOLATILITY {
+		t.Errorf("Players differ between CSV and JSON Lines: \nCSV:   %v\nJSONL: %v", csvPlayers[2], jsonlPlayers[2])
+	}
+}
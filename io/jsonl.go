@@ -0,0 +1,104 @@
+package glicko2io
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Too-Zestyy/glicko2go"
+)
+
+// MAX_JSONL_LINE_LENGTH is the longest line, in bytes, that JSON Lines readers accept.
+const MAX_JSONL_LINE_LENGTH = 1024 * 1024
+
+// jsonLinesScanner reads non-empty lines, tracking the current line number.
+type jsonLinesScanner struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newJSONLinesScanner(r io.Reader) *jsonLinesScanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MAX_JSONL_LINE_LENGTH)
+	return &jsonLinesScanner{scanner: scanner}
+}
+
+// next returns the next non-empty line, or io.EOF once every line has been read.
+func (s *jsonLinesScanner) next() ([]byte, error) {
+	for s.scanner.Scan() {
+		s.line++
+		if line := bytes.TrimSpace(s.scanner.Bytes()); len(line) > 0 {
+			return line, nil
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, &ParseError{Line: s.line + 1, Err: err}
+	}
+	return nil, io.EOF
+}
+
+// JSONLMatchReader streams Glicko2MatchByID values from JSON Lines,
+// where each line is encoded as with encoding/json, e.g. `{"player1_id":1,"player2_id":2,"result":1}`.
+type JSONLMatchReader struct {
+	lines *jsonLinesScanner
+}
+
+// NewJSONLMatchReader creates a JSONLMatchReader reading from `r`. Empty lines are skipped.
+func NewJSONLMatchReader(r io.Reader) *JSONLMatchReader {
+	return &JSONLMatchReader{lines: newJSONLinesScanner(r)}
+}
+
+// Read returns the next match, or io.EOF once every match has been read.
+func (m *JSONLMatchReader) Read() (glicko2go.Glicko2MatchByID, error) {
+	line, err := m.lines.next()
+	if err != nil {
+		return glicko2go.Glicko2MatchByID{}, err
+	}
+
+	var match glicko2go.Glicko2MatchByID
+	if err := json.Unmarshal(line, &match); err != nil {
+		return glicko2go.Glicko2MatchByID{}, &ParseError{Line: m.lines.line, Err: err}
+	}
+	return match, nil
+}
+
+// jsonPlayer is a player snapshot within JSON Lines. Volatility is optional.
+type jsonPlayer struct {
+	ID               *int     `json:"id"`
+	Rating           float64  `json:"rating"`
+	RatingDeviation  float64  `json:"rating_deviation"`
+	RatingVolatility *float64 `json:"rating_volatility"`
+}
+
+// ReadPlayersJSONL streams player snapshots from JSON Lines into a map keyed by player ID, where each line is encoded as
+// `{"id":1,"rating":0,"rating_deviation":2.014761872416068,"rating_volatility":0.06}`. Only options.Scale is used.
+// A *ParseError is returned for the first invalid line, or if a player ID appears more than once.
+func ReadPlayersJSONL(r io.Reader, options PlayerOptions) (map[int]glicko2go.Glicko2Player, error) {
+	lines := newJSONLinesScanner(r)
+	players := make(map[int]glicko2go.Glicko2Player)
+
+	for {
+		line, err := lines.next()
+		if err == io.EOF {
+			return players, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var decoded jsonPlayer
+		if err := json.Unmarshal(line, &decoded); err != nil {
+			return nil, &ParseError{Line: lines.line, Err: err}
+		}
+		if decoded.ID == nil {
+			return nil, &ParseError{Line: lines.line, Err: fmt.Errorf("missing player ID")}
+		}
+		if _, ok := players[*decoded.ID]; ok {
+			return nil, &ParseError{Line: lines.line, Err: fmt.Errorf("duplicate player ID %v", *decoded.ID)}
+		}
+
+		players[*decoded.ID] = options.newPlayer(decoded.Rating, decoded.RatingDeviation, decoded.RatingVolatility)
+	}
+}
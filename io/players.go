@@ -0,0 +1,146 @@
+package glicko2io
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Too-Zestyy/glicko2go"
+)
+
+// glicko2Scale reads values as-is, as they are already on the Glicko 2 scale.
+var glicko2Scale = glicko2go.NewScale(0, 1)
+
+// PlayerColumns maps the fields of a player to zero-based CSV column indexes.
+// A negative Volatility means that players are read with the scale's default volatility,
+// as are records where the volatility column is missing or empty.
+type PlayerColumns struct {
+	ID         int
+	Rating     int
+	Deviation  int
+	Volatility int
+}
+
+// DefaultPlayerColumns reads players as `id,rating,deviation,volatility`.
+var DefaultPlayerColumns = PlayerColumns{ID: 0, Rating: 1, Deviation: 2, Volatility: 3}
+
+// PlayerOptions configures how player snapshots are read.
+type PlayerOptions struct {
+	// Comma is the CSV field delimiter. If 0, ',' is used. Ignored for JSON Lines.
+	Comma rune
+	// HasHeader skips the first CSV record. Ignored for JSON Lines.
+	HasHeader bool
+	// Columns is used to find each CSV field. Ignored for JSON Lines.
+	Columns PlayerColumns
+	// Scale is the scale that ratings and deviations are stored on, such as glicko2go.NewStandardScale().
+	// If nil, values are read as-is on the Glicko 2 scale.
+	Scale *glicko2go.Scale
+}
+
+// DefaultPlayerOptions reads headerless `id,rating,deviation,volatility` records on the Glicko 2 scale.
+func DefaultPlayerOptions() PlayerOptions {
+	return PlayerOptions{Columns: DefaultPlayerColumns}
+}
+
+// Validate returns an error wrapping ErrInvalidOptions if two fields share a column,
+// such as in a zero-valued PlayerOptions, or if a required column is negative.
+func (options PlayerOptions) Validate() error {
+	columns := options.Columns
+	if columns.ID < 0 || columns.Rating < 0 || columns.Deviation < 0 {
+		return fmt.Errorf("%w: player ID, rating and deviation columns must be non-negative, got %+v", ErrInvalidOptions, columns)
+	}
+	if columns.ID == columns.Rating || columns.ID == columns.Deviation || columns.Rating == columns.Deviation ||
+		(columns.Volatility >= 0 && (columns.Volatility == columns.ID || columns.Volatility == columns.Rating || columns.Volatility == columns.Deviation)) {
+		return fmt.Errorf("%w: each field must use a different column, got %+v", ErrInvalidOptions, columns)
+	}
+	return nil
+}
+
+// newPlayer creates a Glicko2Player from values stored on `options.Scale`.
+func (options PlayerOptions) newPlayer(rating float64, deviation float64, volatility *float64) glicko2go.Glicko2Player {
+	scale := options.Scale
+	if scale == nil {
+		scale = &glicko2Scale
+	}
+
+	playerVolatility := scale.DefaultVolatility
+	if volatility != nil {
+		playerVolatility = *volatility
+	}
+
+	return scale.ConvertToGlicko2(glicko2go.GlickoPlayer{Rating: rating, RatingDeviation: deviation}, playerVolatility)
+}
+
+// ReadPlayersCSV streams player snapshots from CSV records into a map keyed by player ID.
+// A *ParseError is returned for the first invalid record, or if a player ID appears more than once.
+// If `options` are invalid, an error wrapping ErrInvalidOptions is returned before anything is read.
+func ReadPlayersCSV(r io.Reader, options PlayerOptions) (map[int]glicko2go.Glicko2Player, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	reader.TrimLeadingSpace = true
+
+	players := make(map[int]glicko2go.Glicko2Player)
+
+	for skipHeader := options.HasHeader; ; skipHeader = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return players, nil
+		}
+		if err != nil {
+			var csvErr *csv.ParseError
+			if errors.As(err, &csvErr) {
+				return nil, &ParseError{Line: csvErr.Line, Err: csvErr.Err}
+			}
+			return nil, err
+		}
+		if skipHeader {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		id, player, err := parsePlayerRecord(record, options)
+		if err != nil {
+			return nil, &ParseError{Line: line, Err: err}
+		}
+		if _, ok := players[id]; ok {
+			return nil, &ParseError{Line: line, Err: fmt.Errorf("duplicate player ID %v", id)}
+		}
+		players[id] = player
+	}
+}
+
+func parsePlayerRecord(record []string, options PlayerOptions) (int, glicko2go.Glicko2Player, error) {
+	id, err := parseIntField(record, options.Columns.ID, "player ID")
+	if err != nil {
+		return 0, glicko2go.Glicko2Player{}, err
+	}
+	rating, err := parseFloatField(record, options.Columns.Rating, "rating")
+	if err != nil {
+		return 0, glicko2go.Glicko2Player{}, err
+	}
+	deviation, err := parseFloatField(record, options.Columns.Deviation, "deviation")
+	if err != nil {
+		return 0, glicko2go.Glicko2Player{}, err
+	}
+
+	// Volatility is optional, so that files from the original Glicko system can be read
+	var volatility *float64
+	if options.Columns.Volatility >= 0 && options.Columns.Volatility < len(record) && record[options.Columns.Volatility] != "" {
+		value, err := parseFloatField(record, options.Columns.Volatility, "volatility")
+		if err != nil {
+			return 0, glicko2go.Glicko2Player{}, err
+		}
+		volatility = &value
+	}
+
+	return id, options.newPlayer(rating, deviation, volatility), nil
+}
@@ -0,0 +1,147 @@
+package glicko2go
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// GLICKO2_DEFAULT_LEADERBOARD_DEVIATION_MULTIPLIER ranks players by the lower bound of roughly a 95% confidence interval of their rating.
+const GLICKO2_DEFAULT_LEADERBOARD_DEVIATION_MULTIPLIER float64 = 2
+
+// LeaderboardOptions determines how BuildLeaderboard scores, filters and pages players.
+type LeaderboardOptions[ID cmp.Ordered] struct {
+	// DeviationMultiplier is `k`, where each player is ranked by the conservative score `rating - k·deviation`.
+	// If 0, players are ranked by rating alone.
+	DeviationMultiplier float64
+	// Scale is the scale used for scores, ratings, deviations and MaxDeviation, such as NewStandardScale().
+	// If nil, the Glicko 2 scale is used.
+	Scale *Scale
+
+	// GamesPlayed holds the number of games each player has played, for MinGames. Players that are missing have played 0 games.
+	GamesPlayed map[ID]int
+	// MinGames excludes players that have played fewer games than this.
+	MinGames int
+	// MaxDeviation excludes players whose deviation on Scale is greater than this. If 0, no players are excluded.
+	MaxDeviation float64
+	// Provisional marks entries as provisional, using GamesPlayed. If left as the zero value, no entries are provisional.
+	Provisional ProvisionalSettings
+	// ExcludeProvisional excludes provisional players, rather than only marking them.
+	ExcludeProvisional bool
+
+	// Offset skips this many entries from the top of the leaderboard, for pagination.
+	Offset int
+	// Limit is the maximum number of entries returned. If 0, every entry after Offset is returned.
+	Limit int
+}
+
+// DefaultLeaderboardOptions ranks every player on the Glicko scale, using GLICKO2_DEFAULT_LEADERBOARD_DEVIATION_MULTIPLIER.
+// Scale points to a new copy of the standard scale, so it can be changed without affecting any other options.
+func DefaultLeaderboardOptions[ID cmp.Ordered]() LeaderboardOptions[ID] {
+	scale := NewStandardScale()
+	return LeaderboardOptions[ID]{
+		DeviationMultiplier: GLICKO2_DEFAULT_LEADERBOARD_DEVIATION_MULTIPLIER,
+		Scale:               &scale,
+	}
+}
+
+// LeaderboardEntry is a single ranked player. Every value other than Player is on the leaderboard's scale.
+type LeaderboardEntry[ID cmp.Ordered] struct {
+	ID ID `json:"id"`
+	// Rank starts from 1. Players with equal scores share a rank, and the following rank is skipped (e.g. 1, 2, 2, 4).
+	Rank int `json:"rank"`
+	// Percentile is the percentage of eligible players scoring below this player, where other tied players count as half below.
+	Percentile      float64 `json:"percentile"`
+	Score           float64 `json:"score"`
+	Rating          float64 `json:"rating"`
+	RatingDeviation float64 `json:"rating_deviation"`
+	Provisional     bool    `json:"provisional"`
+	// Player holds the player as given to BuildLeaderboard, on the Glicko 2 scale.
+	Player Glicko2Player `json:"player"`
+}
+
+// Leaderboard is a page of ranked players.
+type Leaderboard[ID cmp.Ordered] struct {
+	Entries []LeaderboardEntry[ID] `json:"entries"`
+	// Eligible is the number of players that passed every filter, including those outside of the page.
+	Eligible int `json:"eligible"`
+}
+
+// BuildLeaderboard ranks every eligible player by their conservative score, highest first.
+// Tied players are ordered by lowest deviation, then by ID, so pages are stable.
+func BuildLeaderboard[ID cmp.Ordered](players map[ID]Glicko2Player, options LeaderboardOptions[ID]) (Leaderboard[ID], error) {
+	if options.Offset < 0 || options.Limit < 0 {
+		return Leaderboard[ID]{}, fmt.Errorf("leaderboard offset and limit cannot be negative, got %v and %v", options.Offset, options.Limit)
+	}
+	if options.MaxDeviation < 0 {
+		return Leaderboard[ID]{}, fmt.Errorf("leaderboard max deviation cannot be negative, got %v", options.MaxDeviation)
+	}
+
+	scale := options.Scale
+	if scale == nil {
+		glicko2Scale := NewScale(0, 1)
+		scale = &glicko2Scale
+	}
+
+	entries := make([]LeaderboardEntry[ID], 0, len(players))
+	for id, player := range players {
+		if options.GamesPlayed[id] < options.MinGames {
+			continue
+		}
+		provisional := options.Provisional.IsProvisional(player, options.GamesPlayed[id])
+		if provisional && options.ExcludeProvisional {
+			continue
+		}
+
+		scaledPlayer := scale.ConvertFromGlicko2(player)
+		if options.MaxDeviation > 0 && scaledPlayer.RatingDeviation > options.MaxDeviation {
+			continue
+		}
+
+		entries = append(entries, LeaderboardEntry[ID]{
+			ID:              id,
+			Score:           scaledPlayer.Rating - options.DeviationMultiplier*scaledPlayer.RatingDeviation,
+			Rating:          scaledPlayer.Rating,
+			RatingDeviation: scaledPlayer.RatingDeviation,
+			Provisional:     provisional,
+			Player:          player,
+		})
+	}
+
+	slices.SortFunc(entries, func(a, b LeaderboardEntry[ID]) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.RatingDeviation, b.RatingDeviation),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+
+	// Ranks and percentiles are assigned to each group of tied entries
+	for groupStart := 0; groupStart < len(entries); {
+		groupEnd := groupStart + 1
+		for groupEnd < len(entries) && entries[groupEnd].Score == entries[groupStart].Score {
+			groupEnd++
+		}
+
+		tied := groupEnd - groupStart
+		below := len(entries) - groupEnd
+		percentile := (float64(below) + float64(tied-1)/2) / float64(len(entries)) * 100
+
+		for idx := groupStart; idx < groupEnd; idx++ {
+			entries[idx].Rank = groupStart + 1
+			entries[idx].Percentile = percentile
+		}
+		groupStart = groupEnd
+	}
+
+	leaderboard := Leaderboard[ID]{Eligible: len(entries)}
+
+	start := min(options.Offset, len(entries))
+	end := len(entries)
+	if options.Limit > 0 {
+		end = min(start+options.Limit, end)
+	}
+	leaderboard.Entries = entries[start:end]
+
+	return leaderboard, nil
+}
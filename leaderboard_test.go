@@ -0,0 +1,125 @@
+package glicko2go
+
+import (
+	"math"
+	"testing"
+)
+
+// getLeaderboardPlayers returns players where a high rating alone is not enough to top a conservative leaderboard.
+func getLeaderboardPlayers() map[int]Glicko2Player {
+	return map[int]Glicko2Player{
+		// Barely tested, with the highest rating
+		1: ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{Rating: 1900, RatingDeviation: 350}),
+		2: ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{Rating: 1700, RatingDeviation: 50}),
+		// Players 3 and 4 are tied
+		3: ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{Rating: 1600, RatingDeviation: 60}),
+		4: ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{Rating: 1600, RatingDeviation: 60}),
+		5: ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{Rating: 1400, RatingDeviation: 40}),
+	}
+}
+
+func TestBuildLeaderboard(t *testing.T) {
+	leaderboard, err := BuildLeaderboard(getLeaderboardPlayers(), DefaultLeaderboardOptions[int]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedIDs := []int{2, 3, 4, 5, 1}
+	expectedRanks := []int{1, 2, 2, 4, 5}
+	expectedPercentiles := []float64{80, 50, 50, 20, 0}
+	if len(leaderboard.Entries) != len(expectedIDs) || leaderboard.Eligible != len(expectedIDs) {
+		t.Fatalf("Expected %v entries, got %+v", len(expectedIDs), leaderboard)
+	}
+	for idx, entry := range leaderboard.Entries {
+		if entry.ID != expectedIDs[idx] || entry.Rank != expectedRanks[idx] || math.Abs(entry.Percentile-expectedPercentiles[idx]) > 1e-9 {
+			t.Errorf("Expected player %v at rank %v (percentile %v), got %+v", expectedIDs[idx], expectedRanks[idx], expectedPercentiles[idx], entry)
+		}
+	}
+
+	if score := leaderboard.Entries[0].Score; math.Abs(score-1600) > 1e-9 {
+		t.Errorf("Expected player 2 to score 1700 - 2·50 = 1600 on the Glicko scale, got %v", score)
+	}
+
+	// Without the deviation multiplier, the highest rating wins
+	options := DefaultLeaderboardOptions[int]()
+	options.DeviationMultiplier = 0
+	if byRating, _ := BuildLeaderboard(getLeaderboardPlayers(), options); byRating.Entries[0].ID != 1 {
+		t.Errorf("Expected player 1 to top a leaderboard by rating alone, got %+v", byRating.Entries[0])
+	}
+}
+
+func TestBuildLeaderboardFilters(t *testing.T) {
+	options := DefaultLeaderboardOptions[int]()
+	options.GamesPlayed = map[int]int{1: 30, 2: 30, 3: 2, 4: 30}
+	options.MinGames = 10
+	options.MaxDeviation = 300
+
+	leaderboard, err := BuildLeaderboard(getLeaderboardPlayers(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Player 1's deviation is too high, while players 3 and 5 have not played enough games
+	if leaderboard.Eligible != 2 || leaderboard.Entries[0].ID != 2 || leaderboard.Entries[1].ID != 4 {
+		t.Errorf("Expected only players 2 and 4, got %+v", leaderboard.Entries)
+	}
+	if leaderboard.Entries[1].Rank != 2 || leaderboard.Entries[1].Percentile != 0 {
+		t.Errorf("Expected ranks and percentiles to only count eligible players, got %+v", leaderboard.Entries[1])
+	}
+}
+
+func TestBuildLeaderboardPagination(t *testing.T) {
+	options := DefaultLeaderboardOptions[int]()
+	options.Offset = 2
+	options.Limit = 2
+
+	leaderboard, err := BuildLeaderboard(getLeaderboardPlayers(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaderboard.Eligible != 5 || len(leaderboard.Entries) != 2 || leaderboard.Entries[0].ID != 4 || leaderboard.Entries[0].Rank != 2 {
+		t.Errorf("Unexpected second page: %+v", leaderboard)
+	}
+
+	options.Offset = 10
+	if pastEnd, err := BuildLeaderboard(getLeaderboardPlayers(), options); err != nil || len(pastEnd.Entries) != 0 {
+		t.Errorf("Expected an empty page past the end, got %+v (%v)", pastEnd, err)
+	}
+
+	options.Offset = -1
+	if _, err := BuildLeaderboard(getLeaderboardPlayers(), options); err == nil {
+		t.Errorf("A negative offset did not cause an error")
+	}
+}
+
+func TestBuildLeaderboardGlicko2Scale(t *testing.T) {
+	options := DefaultLeaderboardOptions[int]()
+	options.Scale = nil
+
+	leaderboard, err := BuildLeaderboard(getLeaderboardPlayers(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	top := leaderboard.Entries[0]
+	expectedScore := top.Player.Rating - GLICKO2_DEFAULT_LEADERBOARD_DEVIATION_MULTIPLIER*top.Player.RatingDeviation
+	if top.ID != 2 || math.Abs(top.Score-expectedScore) > 1e-12 {
+		t.Errorf("Expected player 2 to score %v on the Glicko 2 scale, got %+v", expectedScore, top)
+	}
+}
+
+// TestDefaultLeaderboardOptionsScaleIsACopy checks that changing the default options' scale does not affect package conversions.
+func TestDefaultLeaderboardOptionsScaleIsACopy(t *testing.T) {
+	expectedRating := GlickoRatingToGlicko2(1700)
+
+	options := DefaultLeaderboardOptions[int]()
+	options.Scale.Centre = 0
+	options.Scale.Factor = 1
+
+	if rating := GlickoRatingToGlicko2(1700); rating != expectedRating {
+		t.Errorf("Changing the default leaderboard scale changed GlickoRatingToGlicko2(1700) from %v to %v", expectedRating, rating)
+	}
+	if otherOptions := DefaultLeaderboardOptions[int](); otherOptions.Scale.Centre != GLICKO_SCALE_CENTRE {
+		t.Errorf("Changing the default leaderboard scale affected other default options: %+v", *otherOptions.Scale)
+	}
+}
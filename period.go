@@ -1,51 +1,77 @@
 package glicko2go
 
-func PeriodCalculatorWithSettings(settings Glicko2AlgorithmSettings) func(
-	players map[int]Glicko2Player,
-	matches []Glicko2MatchByID) (map[int]Glicko2Player, error) {
+// GenericPeriodCalculatorWithPolicy returns a function that calculates every player's stats after a period,
+// where players are identified by any comparable `ID` type (such as `int64` database keys or UUID strings).
+// Equivalent to UpdatePeriodByID.
+//
+// With MATCH_VALIDATION_STRICT, any invalid match causes a *MatchValidationError to be returned and no players are updated.
+// With MATCH_VALIDATION_LENIENT, invalid matches are skipped and returned as a report alongside the updated players.
+func GenericPeriodCalculatorWithPolicy[ID comparable](settings Glicko2AlgorithmSettings, policy MatchValidationPolicy) func(
+	players map[ID]Glicko2Player,
+	matches []Glicko2Match[ID]) (map[ID]Glicko2Player, []MatchIssue[ID], error) {
 
-	newPlayerUpdater := NewPlayerUpdaterWithSettings(settings)
+	rater := NewRater(settings)
 
-	return func(players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error) {
-
-		updatedPlayers := make(map[int]Glicko2Player)
+	return func(players map[ID]Glicko2Player, matches []Glicko2Match[ID]) (map[ID]Glicko2Player, []MatchIssue[ID], error) {
+		return UpdatePeriodByID(rater, players, matches, policy)
+	}
+}
 
-		newMatchLists := make(map[int][]Glicko2MatchForPlayer)
+// GenericPeriodCalculatorWithSettings returns a GenericPeriodCalculatorWithPolicy function using MATCH_VALIDATION_STRICT.
+func GenericPeriodCalculatorWithSettings[ID comparable](settings Glicko2AlgorithmSettings) func(
+	players map[ID]Glicko2Player,
+	matches []Glicko2Match[ID]) (map[ID]Glicko2Player, error) {
 
-		for _, match := range matches {
-			//match = match
-			newMatchLists[match.Player1ID] = append(newMatchLists[match.Player1ID], Glicko2MatchForPlayer{
-				Opponent: players[match.Player2ID],
-				Result:   match.Result,
-			},
-			)
+	periodCalculator := GenericPeriodCalculatorWithPolicy[ID](settings, MATCH_VALIDATION_STRICT)
 
-			newMatchLists[match.Player2ID] = append(newMatchLists[match.Player2ID], Glicko2MatchForPlayer{
-				Opponent: players[match.Player1ID],
-				Result:   1 - match.Result,
-			},
-			)
+	return func(players map[ID]Glicko2Player, matches []Glicko2Match[ID]) (map[ID]Glicko2Player, error) {
+		updatedPlayers, _, err := periodCalculator(players, matches)
+		return updatedPlayers, err
+	}
+}
 
-		}
+// GenericDefaultPeriodCalculator provides default settings for GenericPeriodCalculatorWithSettings.
+func GenericDefaultPeriodCalculator[ID comparable]() func(players map[ID]Glicko2Player, matches []Glicko2Match[ID]) (map[ID]Glicko2Player, error) {
+	return GenericPeriodCalculatorWithSettings[ID](glicko2DefaultSettings)
+}
 
-		for playerID, player := range players {
+// GenericElapsedPeriodCalculatorWithSettings returns a function equivalent to GenericPeriodCalculatorWithSettings,
+// that grows each player's deviation by their number of periods within `elapsedPeriods`. See UpdatePeriodByIDAfterElapsed.
+func GenericElapsedPeriodCalculatorWithSettings[ID comparable](settings Glicko2AlgorithmSettings) func(
+	players map[ID]Glicko2Player,
+	matches []Glicko2Match[ID],
+	elapsedPeriods map[ID]float64) (map[ID]Glicko2Player, error) {
 
-			var playerMatchList []Glicko2MatchForPlayer
-			if filledMatchList, ok := newMatchLists[playerID]; ok {
-				playerMatchList = filledMatchList
-			}
-			updatedPlayer, err := newPlayerUpdater(player, playerMatchList)
+	rater := NewRater(settings)
 
-			if err != nil {
-				return nil, err
-			}
+	return func(players map[ID]Glicko2Player, matches []Glicko2Match[ID], elapsedPeriods map[ID]float64) (map[ID]Glicko2Player, error) {
+		updatedPlayers, _, err := UpdatePeriodByIDAfterElapsed(rater, players, matches, MATCH_VALIDATION_STRICT, elapsedPeriods)
+		return updatedPlayers, err
+	}
+}
 
-			updatedPlayers[playerID] = updatedPlayer
+// PeriodCalculatorWithPolicy is GenericPeriodCalculatorWithPolicy for `int` player IDs.
+// Equivalent to Rater.UpdatePeriodWithPolicy.
+func PeriodCalculatorWithPolicy(settings Glicko2AlgorithmSettings, policy MatchValidationPolicy) func(
+	players map[int]Glicko2Player,
+	matches []Glicko2MatchByID) (map[int]Glicko2Player, []MatchIssue[int], error) {
+	return GenericPeriodCalculatorWithPolicy[int](settings, policy)
+}
 
-		}
+// PeriodCalculatorWithSettings is GenericPeriodCalculatorWithSettings for `int` player IDs,
+// so any invalid match fails the whole period with a *MatchValidationError. Equivalent to Rater.UpdatePeriod.
+func PeriodCalculatorWithSettings(settings Glicko2AlgorithmSettings) func(
+	players map[int]Glicko2Player,
+	matches []Glicko2MatchByID) (map[int]Glicko2Player, error) {
+	return GenericPeriodCalculatorWithSettings[int](settings)
+}
 
-		return updatedPlayers, nil
-	}
+// ElapsedPeriodCalculatorWithSettings is GenericElapsedPeriodCalculatorWithSettings for `int` player IDs.
+func ElapsedPeriodCalculatorWithSettings(settings Glicko2AlgorithmSettings) func(
+	players map[int]Glicko2Player,
+	matches []Glicko2MatchByID,
+	elapsedPeriods map[int]float64) (map[int]Glicko2Player, error) {
+	return GenericElapsedPeriodCalculatorWithSettings[int](settings)
 }
 
 func DefaultPeriodCalculator() func(players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error) {
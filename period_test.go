@@ -1,8 +1,14 @@
 package glicko2go
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
 	"testing"
+	"time"
 )
 
 // getAllBinaryPermutationsOfLength returns all binary permutations of length `len`.
@@ -216,3 +222,288 @@ func TestPeriodCalculatorMatchInversion(t *testing.T) {
 	}
 
 }
+
+// TestPeriodCalculatorRejectsInvalidMatches ensures that strict period calculators report every invalid match,
+// and that lenient ones skip them without affecting valid matches.
+func TestPeriodCalculatorRejectsInvalidMatches(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+
+	invalidMatches := append(getExampleMatchList(),
+		Glicko2MatchByID{Player1ID: 1, Player2ID: 99, Result: GAME_OUTCOME_WIN},
+		Glicko2MatchByID{Player1ID: 2, Player2ID: 2, Result: GAME_OUTCOME_DRAW},
+		Glicko2MatchByID{Player1ID: 3, Player2ID: 4, Result: 1.5},
+	)
+
+	_, err := DefaultPeriodCalculator()(players, invalidMatches)
+	var validationErr *MatchValidationError[int]
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a *MatchValidationError, got: %v", err)
+	}
+
+	expectedKinds := []MatchIssueKind{MATCH_ISSUE_UNKNOWN_PLAYER, MATCH_ISSUE_SELF_PLAY, MATCH_ISSUE_RESULT_OUT_OF_RANGE}
+	if len(validationErr.Issues) != len(expectedKinds) {
+		t.Fatalf("Expected %v issues, got %v: %v", len(expectedKinds), len(validationErr.Issues), validationErr)
+	}
+	for i, kind := range expectedKinds {
+		if validationErr.Issues[i].Kind != kind || validationErr.Issues[i].MatchIndex != len(matchList)+i {
+			t.Errorf("Unexpected issue at position %v: %v", i, validationErr.Issues[i])
+		}
+	}
+	if validationErr.Issues[0].PlayerID != 99 {
+		t.Errorf("Unknown player issue does not report the unknown ID: %v", validationErr.Issues[0])
+	}
+
+	lenientCalculator := PeriodCalculatorWithPolicy(glicko2DefaultSettings, MATCH_VALIDATION_LENIENT)
+	lenientPlayers, issues, err := lenientCalculator(players, invalidMatches)
+	if err != nil {
+		t.Fatalf("Lenient period calculator returned an error: %v", err)
+	}
+	if len(issues) != len(expectedKinds) {
+		t.Errorf("Lenient period calculator did not report skipped matches: %v", issues)
+	}
+
+	referencePlayers, err := DefaultPeriodCalculator()(players, matchList)
+	if err != nil {
+		t.Fatalf("Error calculating reference results: %v", err)
+	}
+	for id := range referencePlayers {
+		if referencePlayers[id] != lenientPlayers[id] {
+			t.Errorf("Player %v is affected by skipped matches \nExpected: %v\nGot:      %v", id, referencePlayers[id], lenientPlayers[id])
+		}
+	}
+}
+
+// TestGenericPeriodCalculatorMatchesIntIDs ensures that using string IDs gives identical results to the `int` period calculator.
+func TestGenericPeriodCalculatorMatchesIntIDs(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+
+	stringPlayers := make(map[string]Glicko2Player)
+	for id, player := range players {
+		stringPlayers[fmt.Sprintf("player-%v", id)] = player
+	}
+	var stringMatchList []Glicko2Match[string]
+	for _, match := range matchList {
+		stringMatchList = append(stringMatchList, Glicko2Match[string]{
+			Player1ID: fmt.Sprintf("player-%v", match.Player1ID),
+			Player2ID: fmt.Sprintf("player-%v", match.Player2ID),
+			Result:    match.Result,
+		})
+	}
+
+	referencePlayers, err := DefaultPeriodCalculator()(players, matchList)
+	if err != nil {
+		t.Fatalf("Error calculating reference results: %v", err)
+	}
+	stringPostPeriodPlayers, err := GenericDefaultPeriodCalculator[string]()(stringPlayers, stringMatchList)
+	if err != nil {
+		t.Fatalf("Error calculating results with string IDs: %v", err)
+	}
+
+	for id, player := range referencePlayers {
+		if stringPlayer := stringPostPeriodPlayers[fmt.Sprintf("player-%v", id)]; stringPlayer != player {
+			t.Errorf("Player %v differs when using string IDs \nInt IDs:    %v\nString IDs: %v", id, player, stringPlayer)
+		}
+	}
+}
+
+// TestConcurrentPeriodCalculatorMatchesSequential ensures that concurrent period calculators give bit-identical results
+// to the sequential period calculator, for a range of worker counts.
+func TestConcurrentPeriodCalculatorMatchesSequential(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	players := make(map[int]Glicko2Player)
+	for id := 0; id < 500; id++ {
+		players[id] = ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{
+			Rating:          1000 + random.Float64()*1000,
+			RatingDeviation: 30 + random.Float64()*320,
+		})
+	}
+	var matchList []Glicko2MatchByID
+	for i := 0; i < 2000; i++ {
+		player1ID, player2ID := random.Intn(len(players)), random.Intn(len(players)-1)
+		if player2ID >= player1ID {
+			player2ID++
+		}
+		matchList = append(matchList, Glicko2MatchByID{
+			Player1ID: player1ID,
+			Player2ID: player2ID,
+			Result:    []float64{GAME_OUTCOME_LOSS, GAME_OUTCOME_DRAW, GAME_OUTCOME_WIN}[random.Intn(3)],
+		})
+	}
+
+	referencePlayers, err := DefaultPeriodCalculator()(players, matchList)
+	if err != nil {
+		t.Fatalf("Error calculating reference results: %v", err)
+	}
+
+	for _, workers := range []int{0, 1, 3, 16} {
+		postPeriodPlayers, err := ConcurrentPeriodCalculatorWithSettings(glicko2DefaultSettings, workers)(context.Background(), players, matchList)
+		if err != nil {
+			t.Fatalf("Error calculating results with %v workers: %v", workers, err)
+		}
+		if len(postPeriodPlayers) != len(referencePlayers) {
+			t.Fatalf("Expected %v players with %v workers, got %v", len(referencePlayers), workers, len(postPeriodPlayers))
+		}
+		for id, player := range referencePlayers {
+			if postPeriodPlayers[id] != player {
+				t.Errorf("Player %v differs with %v workers \nSequential: %v\nConcurrent: %v", id, workers, player, postPeriodPlayers[id])
+			}
+		}
+	}
+}
+
+func TestConcurrentPeriodCalculatorCancellation(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ConcurrentPeriodCalculatorWithSettings(glicko2DefaultSettings, 2)(ctx, players, matchList)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from a cancelled period, got: %v", err)
+	}
+}
+
+// TestScheduledPeriodCalculator ensures that matches are split into the correct periods,
+// and that empty periods still increase deviations.
+func TestScheduledPeriodCalculator(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	// Play the example on the first and third days, leaving the second day empty
+	var scheduledMatches []Glicko2MatchByID
+	for i, match := range matchList {
+		match.PlayedAt = start.Add(time.Duration(i) * time.Hour)
+		scheduledMatches = append(scheduledMatches, match)
+	}
+	for i, match := range matchList {
+		match.PlayedAt = start.AddDate(0, 0, 2).Add(time.Duration(i) * time.Minute)
+		scheduledMatches = append(scheduledMatches, match)
+	}
+
+	scheduledCalculator := ScheduledPeriodCalculatorWithSettings(glicko2DefaultSettings, DailyPeriodSchedule(start))
+	scheduledPlayers, periods, err := scheduledCalculator(players, scheduledMatches, start.AddDate(0, 0, 3))
+	if err != nil {
+		t.Fatalf("Error calculating scheduled periods: %v", err)
+	}
+
+	expectedMatchCounts := []int{len(matchList), 0, len(matchList)}
+	if len(periods) != len(expectedMatchCounts) {
+		t.Fatalf("Expected %v periods, got %v", len(expectedMatchCounts), len(periods))
+	}
+	for i, period := range periods {
+		if len(period.Matches) != expectedMatchCounts[i] {
+			t.Errorf("Expected %v matches in period %v, got %v", expectedMatchCounts[i], i, len(period.Matches))
+		}
+	}
+
+	periodCalculator := DefaultPeriodCalculator()
+	referencePlayers := players
+	for _, periodMatches := range [][]Glicko2MatchByID{matchList, nil, matchList} {
+		referencePlayers, err = periodCalculator(referencePlayers, periodMatches)
+		if err != nil {
+			t.Fatalf("Error calculating reference results: %v", err)
+		}
+	}
+
+	for id, player := range referencePlayers {
+		if scheduledPlayers[id] != player {
+			t.Errorf("Player %v differs when scheduled \nExpected: %v\nGot:      %v", id, player, scheduledPlayers[id])
+		}
+	}
+
+	lateMatch := matchList[0]
+	lateMatch.PlayedAt = start.AddDate(0, 0, 3)
+	if _, _, err := scheduledCalculator(players, []Glicko2MatchByID{lateMatch}, start.AddDate(0, 0, 3)); err == nil {
+		t.Errorf("A match played after the end of the schedule did not cause an error")
+	}
+}
+
+// TestElapsedPeriodCalculator ensures that deviations grow by the elapsed number of periods given for each player.
+func TestElapsedPeriodCalculator(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+	players[5] = ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{
+		Rating:          1500,
+		RatingDeviation: 200,
+	})
+
+	referencePlayers, err := DefaultPeriodCalculator()(players, matchList)
+	if err != nil {
+		t.Fatalf("Error calculating reference results: %v", err)
+	}
+
+	if deviation := players[5].DeviationAfter(1); deviation != referencePlayers[5].RatingDeviation {
+		t.Errorf("Deviation after a single period does not match the period calculator: expected %v, got %v", referencePlayers[5].RatingDeviation, deviation)
+	}
+
+	elapsedPeriods := map[int]float64{1: 0.5, 5: 0.25}
+	elapsedPlayers, err := ElapsedPeriodCalculatorWithSettings(glicko2DefaultSettings)(players, matchList, elapsedPeriods)
+	if err != nil {
+		t.Fatalf("Error calculating results with elapsed periods: %v", err)
+	}
+
+	if expected := players[5].AfterInactivity(0.25); elapsedPlayers[5] != expected {
+		t.Errorf("Inactive player does not grow by a fraction of a period \nExpected: %v\nGot:      %v", expected, elapsedPlayers[5])
+	}
+	if !(players[5].RatingDeviation < elapsedPlayers[5].RatingDeviation && elapsedPlayers[5].RatingDeviation < referencePlayers[5].RatingDeviation) {
+		t.Errorf("Deviation after a fraction of a period is not between the pre and post-period deviations: %v", elapsedPlayers[5].RatingDeviation)
+	}
+	if elapsedPlayers[1].RatingDeviation >= referencePlayers[1].RatingDeviation {
+		t.Errorf("Active player's deviation is not lower after half of a period: %v", elapsedPlayers[1].RatingDeviation)
+	}
+	for _, id := range []int{2, 3, 4} {
+		if elapsedPlayers[id] != referencePlayers[id] {
+			t.Errorf("Player %v without an elapsed time is not updated by a single period \nExpected: %v\nGot:      %v", id, referencePlayers[id], elapsedPlayers[id])
+		}
+	}
+}
+
+// TestMaxDeviation ensures that deviations of long-inactive players stop growing at the settings' maximum deviation.
+func TestMaxDeviation(t *testing.T) {
+	players := map[int]Glicko2Player{
+		1: ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{
+			Rating:          1500,
+			RatingDeviation: 340,
+		}),
+	}
+
+	periodCalculator := DefaultPeriodCalculator()
+	for period := 0; period < 100; period++ {
+		var err error
+		players, err = periodCalculator(players, nil)
+		if err != nil {
+			t.Fatalf("Error calculating period %v: %v", period, err)
+		}
+	}
+	if players[1].RatingDeviation != GLICKO2_DEFAULT_MAX_DEVIATION {
+		t.Errorf("Deviation of an inactive player is not capped at %v: %v", GLICKO2_DEFAULT_MAX_DEVIATION, players[1].RatingDeviation)
+	}
+
+	uncappedSettings := glicko2DefaultSettings
+	uncappedSettings.DisableMaxDeviation = true
+	uncappedPlayers, err := PeriodCalculatorWithSettings(uncappedSettings)(players, nil)
+	if err != nil {
+		t.Fatalf("Error calculating uncapped period: %v", err)
+	}
+	if uncappedPlayers[1].RatingDeviation <= GLICKO2_DEFAULT_MAX_DEVIATION {
+		t.Errorf("Deviation is capped when the cap is disabled: %v", uncappedPlayers[1].RatingDeviation)
+	}
+
+	if _, err := json.Marshal(uncappedSettings); err != nil {
+		t.Errorf("Settings with the cap disabled cannot be serialised: %v", err)
+	}
+
+	// Inactivity outside of a period calculator is capped in the same way when settings are given
+	if deviation := players[1].DeviationAfterWithSettings(100, glicko2DefaultSettings); deviation != GLICKO2_DEFAULT_MAX_DEVIATION {
+		t.Errorf("Deviation after inactivity is not capped at %v: %v", GLICKO2_DEFAULT_MAX_DEVIATION, deviation)
+	}
+	if deviation := players[1].DeviationAfter(100); deviation <= GLICKO2_DEFAULT_MAX_DEVIATION {
+		t.Errorf("Deviation after inactivity is capped when no settings are given: %v", deviation)
+	}
+	if deviation := players[1].AfterInactivityWithSettings(100, uncappedSettings).RatingDeviation; deviation <= GLICKO2_DEFAULT_MAX_DEVIATION {
+		t.Errorf("Deviation after inactivity is capped when the cap is disabled: %v", deviation)
+	}
+	if err := (Glicko2AlgorithmSettings{SystemConstant: 0.5, ConvergenceTolerance: 0.000001, MaxDeviation: math.Inf(1)}).Validate(); err == nil {
+		t.Errorf("An infinite max deviation, which cannot be encoded as JSON, did not cause an error")
+	}
+}
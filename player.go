@@ -1,5 +1,7 @@
 package glicko2go
 
+import "math"
+
 const (
 	// GLICKO_DEFAULT_PLAYER_RATING is the default player rating for someone that has not been previously rated,
 	// as described in step 1.
@@ -12,12 +14,13 @@ const (
 	GLICKO2_DEFAULT_PLAYER_VOLATILITY = 0.06
 )
 
+// GLICKO2_PROBABILITY_EPSILON bounds probabilities scored by PredictionLogLoss away from 0 and 1,
+// so a single confident but wrong prediction cannot make log-loss infinite.
+const GLICKO2_PROBABILITY_EPSILON = 1e-15
+
 // NewDefaultGlickoPlayer creates a GlickoPlayer using identical values to NewDefaultGlicko2Player.
 func NewDefaultGlickoPlayer() GlickoPlayer {
-	return GlickoPlayer{
-		Rating:          GLICKO_DEFAULT_PLAYER_RATING,
-		RatingDeviation: GLICKO_DEFAULT_PLAYER_DEVIATION,
-	}
+	return standardScale.NewDefaultGlickoPlayer()
 }
 
 // NewDefaultGlicko2Player creates a Glicko2Player representing a previously unrated player.
@@ -27,5 +30,53 @@ func NewDefaultGlickoPlayer() GlickoPlayer {
 //   - GLICKO_DEFAULT_PLAYER_DEVIATION
 //   - GLICKO2_DEFAULT_PLAYER_VOLATILITY
 func NewDefaultGlicko2Player() Glicko2Player {
-	return ConvertToGlicko2WithDefaultVolatility(NewDefaultGlickoPlayer())
+	return standardScale.NewDefaultGlicko2Player()
+}
+
+// WinProbability returns the probability of `p` beating `opponent`, where a draw counts as half a win.
+//
+// Unlike step3E (and Rater.ExpectedScore), which only uses the opponent's deviation as in the rating update,
+// the uncertainty of both players is accounted for by combining their deviations as `√(φ² + φj²)`.
+func (p Glicko2Player) WinProbability(opponent Glicko2Player) float64 {
+	combinedDeviation := math.Sqrt(math.Pow(p.RatingDeviation, 2) + math.Pow(opponent.RatingDeviation, 2))
+	return step3E(p.Rating, opponent.Rating, combinedDeviation)
+}
+
+// PredictionLogLoss returns the log-loss of predicting a score of `result` with `probability`, such as from WinProbability,
+// where a draw counts as half a win. `probability` is clamped to within GLICKO2_PROBABILITY_EPSILON of 0 and 1.
+func PredictionLogLoss(probability float64, result float64) float64 {
+	probability = math.Min(math.Max(probability, GLICKO2_PROBABILITY_EPSILON), 1-GLICKO2_PROBABILITY_EPSILON)
+	return -(result*math.Log(probability) + (1-result)*math.Log(1-probability))
+}
+
+// WinProbability returns the probability of `p` beating `opponent` on the Glicko scale.
+// Equivalent to Glicko2Player.WinProbability, as the volatility of either player does not affect the result.
+func (p GlickoPlayer) WinProbability(opponent GlickoPlayer) float64 {
+	return ConvertToGlicko2WithDefaultVolatility(p).WinProbability(ConvertToGlicko2WithDefaultVolatility(opponent))
+}
+
+// DeviationAfter returns the player's deviation after `elapsedPeriods` without playing, as `√(φ² + tσ²)`.
+// Unlike a period calculator, `elapsedPeriods` may be a fraction of a period.
+// As no settings are given, the result is not capped by Glicko2AlgorithmSettings.MaxDeviation.
+func (p Glicko2Player) DeviationAfter(elapsedPeriods float64) float64 {
+	return calcElapsedPreRatingDeviation(p.RatingDeviation, p.RatingVolatility, elapsedPeriods)
+}
+
+// DeviationAfterWithSettings is equivalent to DeviationAfter, but capped by the MaxDeviation of `settings`,
+// as with a period calculator using `settings`.
+func (p Glicko2Player) DeviationAfterWithSettings(elapsedPeriods float64, settings Glicko2AlgorithmSettings) float64 {
+	return math.Min(p.DeviationAfter(elapsedPeriods), settings.maxDeviation())
+}
+
+// AfterInactivity returns a copy of the player with their deviation grown by `elapsedPeriods` without playing.
+// See DeviationAfter.
+func (p Glicko2Player) AfterInactivity(elapsedPeriods float64) Glicko2Player {
+	p.RatingDeviation = p.DeviationAfter(elapsedPeriods)
+	return p
+}
+
+// AfterInactivityWithSettings is equivalent to AfterInactivity, but capped by the MaxDeviation of `settings`.
+func (p Glicko2Player) AfterInactivityWithSettings(elapsedPeriods float64, settings Glicko2AlgorithmSettings) Glicko2Player {
+	p.RatingDeviation = p.DeviationAfterWithSettings(elapsedPeriods, settings)
+	return p
 }
@@ -0,0 +1,51 @@
+package glicko2go
+
+// Glicko2MatchPreview holds a player's hypothetical post-period stats for each outcome of a match that has not been played yet.
+type Glicko2MatchPreview struct {
+	Win  Glicko2Player
+	Draw Glicko2Player
+	Loss Glicko2Player
+}
+
+// PreviewMatch calculates what `player`'s stats would be after the period if they played `opponent`,
+// for each of GAME_OUTCOME_WIN, GAME_OUTCOME_DRAW and GAME_OUTCOME_LOSS.
+//
+// `periodGames` are the matches the player has already played within the current period, and are included in each preview.
+// Each preview is calculated via Rater.UpdatePlayer, so it will always match the real update.
+func (r *Rater) PreviewMatch(player Glicko2Player, opponent Glicko2Player, periodGames []Glicko2MatchForPlayer) (Glicko2MatchPreview, error) {
+	previewGames := make([]Glicko2MatchForPlayer, len(periodGames)+1)
+	copy(previewGames, periodGames)
+
+	var outcomes [3]Glicko2Player
+	for i, result := range []float64{GAME_OUTCOME_WIN, GAME_OUTCOME_DRAW, GAME_OUTCOME_LOSS} {
+		previewGames[len(periodGames)] = Glicko2MatchForPlayer{
+			Opponent: opponent,
+			Result:   result,
+		}
+
+		updatedPlayer, err := r.UpdatePlayer(player, previewGames)
+		if err != nil {
+			return Glicko2MatchPreview{}, err
+		}
+		outcomes[i] = updatedPlayer
+	}
+
+	return Glicko2MatchPreview{
+		Win:  outcomes[0],
+		Draw: outcomes[1],
+		Loss: outcomes[2],
+	}, nil
+}
+
+// MatchPreviewerWithSettings returns a function used to preview a player's stats after each outcome of a match.
+// Equivalent to Rater.PreviewMatch.
+func MatchPreviewerWithSettings(settings Glicko2AlgorithmSettings) func(player Glicko2Player,
+	opponent Glicko2Player, periodGames []Glicko2MatchForPlayer) (Glicko2MatchPreview, error) {
+	return NewRater(settings).PreviewMatch
+}
+
+// MatchPreviewerWithDefaultSettings provides default settings for MatchPreviewerWithSettings.
+func MatchPreviewerWithDefaultSettings() func(player Glicko2Player,
+	opponent Glicko2Player, periodGames []Glicko2MatchForPlayer) (Glicko2MatchPreview, error) {
+	return MatchPreviewerWithSettings(glicko2DefaultSettings)
+}
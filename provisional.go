@@ -0,0 +1,144 @@
+package glicko2go
+
+import (
+	"fmt"
+	"math"
+)
+
+// GLICKO2_DEFAULT_PROVISIONAL_DEVIATION is the deviation on the Glicko 2 scale above which DefaultProvisionalSettings
+// considers a player provisional, equivalent to 110 on the Glicko scale.
+const GLICKO2_DEFAULT_PROVISIONAL_DEVIATION = 110 / GLICKO_SCALE_FACTOR
+
+// ProvisionalSettings determines which players are still provisional, and how they are rated during placement.
+// A player is provisional if either criterion that is set applies to them.
+type ProvisionalSettings struct {
+	// DeviationThreshold makes players provisional while their deviation on the Glicko 2 scale is above it. If 0, it is not used.
+	DeviationThreshold float64 `json:"deviation_threshold,omitempty"`
+	// MinGames makes players provisional until they have played this many games. If 0, it is not used.
+	MinGames int `json:"min_games,omitempty"`
+	// PlacementSystemConstant replaces the system constant for players who are provisional at the start of a period,
+	// so their ratings converge faster. If 0, provisional players use the same system constant as everyone else.
+	PlacementSystemConstant float64 `json:"placement_system_constant,omitempty"`
+}
+
+// DefaultProvisionalSettings considers players provisional while their deviation is above GLICKO2_DEFAULT_PROVISIONAL_DEVIATION,
+// without changing how they are rated.
+func DefaultProvisionalSettings() ProvisionalSettings {
+	return ProvisionalSettings{DeviationThreshold: GLICKO2_DEFAULT_PROVISIONAL_DEVIATION}
+}
+
+// Validate checks that the settings are usable. Any returned error wraps ErrInvalidSettings.
+func (s ProvisionalSettings) Validate() error {
+	if s.DeviationThreshold < 0 || math.IsNaN(s.DeviationThreshold) {
+		return fmt.Errorf("%w: provisional deviation threshold cannot be negative, got %v", ErrInvalidSettings, s.DeviationThreshold)
+	}
+	if s.MinGames < 0 {
+		return fmt.Errorf("%w: provisional min games cannot be negative, got %v", ErrInvalidSettings, s.MinGames)
+	}
+	if s.PlacementSystemConstant < 0 || math.IsNaN(s.PlacementSystemConstant) || math.IsInf(s.PlacementSystemConstant, 1) {
+		return fmt.Errorf("%w: placement system constant must be positive and finite, got %v", ErrInvalidSettings, s.PlacementSystemConstant)
+	}
+	return nil
+}
+
+// IsProvisional returns whether a player who has played `gamesPlayed` games is provisional.
+func (s ProvisionalSettings) IsProvisional(player Glicko2Player, gamesPlayed int) bool {
+	return (s.DeviationThreshold > 0 && player.RatingDeviation > s.DeviationThreshold) ||
+		(s.MinGames > 0 && gamesPlayed < s.MinGames)
+}
+
+// placementSettings returns `settings` with the placement system constant, if it has been set.
+func (s ProvisionalSettings) placementSettings(settings Glicko2AlgorithmSettings) Glicko2AlgorithmSettings {
+	if s.PlacementSystemConstant > 0 {
+		settings.SystemConstant = s.PlacementSystemConstant
+	}
+	return settings
+}
+
+// Glicko2PlayerRecord is a player alongside the number of games they have played, as needed to track provisional status.
+type Glicko2PlayerRecord struct {
+	Player      Glicko2Player `json:"player"`
+	GamesPlayed int           `json:"games_played"`
+	// Provisional is set by period calculators from ProvisionalSettings.IsProvisional. See NewPlayerRecord.
+	Provisional bool `json:"provisional"`
+}
+
+// NewPlayerRecord creates a record for `player`, setting Provisional from `provisional`.
+func NewPlayerRecord(player Glicko2Player, gamesPlayed int, provisional ProvisionalSettings) Glicko2PlayerRecord {
+	return Glicko2PlayerRecord{
+		Player:      player,
+		GamesPlayed: gamesPlayed,
+		Provisional: provisional.IsProvisional(player, gamesPlayed),
+	}
+}
+
+// SplitPlayerRecords separates `records` into their players and games played, as used by BuildLeaderboard.
+func SplitPlayerRecords[ID comparable](records map[ID]Glicko2PlayerRecord) (map[ID]Glicko2Player, map[ID]int) {
+	players := make(map[ID]Glicko2Player, len(records))
+	gamesPlayed := make(map[ID]int, len(records))
+	for id, record := range records {
+		players[id] = record.Player
+		gamesPlayed[id] = record.GamesPlayed
+	}
+	return players, gamesPlayed
+}
+
+// UpdateProvisionalPeriodByID is equivalent to UpdatePeriodByID, but rates players held within records, counting the
+// games each player plays and updating their provisional status afterwards.
+//
+// Players that are provisional at the start of the period are rated with the placement system constant, if it has been set.
+// The Provisional field of each record in `records` is ignored, as status is calculated from each player and their games played.
+func UpdateProvisionalPeriodByID[ID comparable](r *Rater, provisional ProvisionalSettings, records map[ID]Glicko2PlayerRecord, matches []Glicko2Match[ID],
+	policy MatchValidationPolicy) (map[ID]Glicko2PlayerRecord, []MatchIssue[ID], error) {
+
+	if err := provisional.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	players, _ := SplitPlayerRecords(records)
+
+	newMatchLists, issues, err := buildPeriodMatchLists(players, matches, policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	placementRater := NewRater(provisional.placementSettings(r.Settings()))
+	updatedRecords := make(map[ID]Glicko2PlayerRecord, len(records))
+
+	for playerID, record := range records {
+		rater := r
+		if provisional.IsProvisional(record.Player, record.GamesPlayed) {
+			rater = placementRater
+		}
+
+		updatedPlayer, err := rater.UpdatePlayer(record.Player, newMatchLists[playerID])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		updatedRecords[playerID] = NewPlayerRecord(updatedPlayer, record.GamesPlayed+len(newMatchLists[playerID]), provisional)
+	}
+
+	return updatedRecords, issues, nil
+}
+
+// GenericProvisionalPeriodCalculatorWithSettings returns a function that calculates every player's record after a period,
+// tracking provisional status with `provisional`. Any invalid match fails the whole period. See UpdateProvisionalPeriodByID.
+func GenericProvisionalPeriodCalculatorWithSettings[ID comparable](settings Glicko2AlgorithmSettings, provisional ProvisionalSettings) func(
+	records map[ID]Glicko2PlayerRecord,
+	matches []Glicko2Match[ID]) (map[ID]Glicko2PlayerRecord, error) {
+
+	rater := NewRater(settings)
+
+	return func(records map[ID]Glicko2PlayerRecord, matches []Glicko2Match[ID]) (map[ID]Glicko2PlayerRecord, error) {
+		updatedRecords, _, err := UpdateProvisionalPeriodByID(rater, provisional, records, matches, MATCH_VALIDATION_STRICT)
+		return updatedRecords, err
+	}
+}
+
+// ProvisionalPeriodCalculatorWithSettings is GenericProvisionalPeriodCalculatorWithSettings for `int` player IDs.
+func ProvisionalPeriodCalculatorWithSettings(settings Glicko2AlgorithmSettings, provisional ProvisionalSettings) func(
+	records map[int]Glicko2PlayerRecord,
+	matches []Glicko2MatchByID) (map[int]Glicko2PlayerRecord, error) {
+	return GenericProvisionalPeriodCalculatorWithSettings[int](settings, provisional)
+}
@@ -0,0 +1,122 @@
+package glicko2go
+
+import (
+	"errors"
+	"testing"
+)
+
+func getExampleRecords(provisional ProvisionalSettings, gamesPlayed int) map[int]Glicko2PlayerRecord {
+	records := make(map[int]Glicko2PlayerRecord)
+	for id, player := range getExamplePlayers() {
+		records[id] = NewPlayerRecord(player, gamesPlayed, provisional)
+	}
+	return records
+}
+
+func TestProvisionalPeriodCalculatorMatchesPeriodCalculator(t *testing.T) {
+	provisional := ProvisionalSettings{MinGames: 3}
+
+	records, err := ProvisionalPeriodCalculatorWithSettings(glicko2DefaultSettings, provisional)(getExampleRecords(provisional, 0), getExampleMatchList())
+	if err != nil {
+		t.Fatal(err)
+	}
+	referencePlayers, err := DefaultPeriodCalculator()(getExamplePlayersAndMatches())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for id, record := range records {
+		if record.Player != referencePlayers[id] {
+			t.Errorf("Player %v differs without a placement system constant\nRecord:    %v\nReference: %v", id, record.Player, referencePlayers[id])
+		}
+	}
+
+	// Player 1 plays all 3 games, while each of their opponents only plays 1
+	for id, expectedGames := range map[int]int{1: 3, 2: 1, 3: 1, 4: 1} {
+		if records[id].GamesPlayed != expectedGames || records[id].Provisional != (expectedGames < 3) {
+			t.Errorf("Expected player %v to have played %v games, got %+v", id, expectedGames, records[id])
+		}
+	}
+}
+
+func TestProvisionalPlacementSystemConstant(t *testing.T) {
+	provisional := ProvisionalSettings{MinGames: 10, PlacementSystemConstant: GLICKO2_HIGH_SYSTEM_CONSTANT}
+
+	// Player 1 is established, while everyone else is still in placement
+	records := getExampleRecords(provisional, 0)
+	records[1] = NewPlayerRecord(records[1].Player, 20, provisional)
+
+	updatedRecords, err := ProvisionalPeriodCalculatorWithSettings(glicko2DefaultSettings, provisional)(records, getExampleMatchList())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	placementSettings := glicko2DefaultSettings
+	placementSettings.SystemConstant = GLICKO2_HIGH_SYSTEM_CONSTANT
+	established, _, _ := UpdatePeriodByID(NewDefaultRater(), getExamplePlayers(), getExampleMatchList(), MATCH_VALIDATION_STRICT)
+	placed, _, _ := UpdatePeriodByID(NewRater(placementSettings), getExamplePlayers(), getExampleMatchList(), MATCH_VALIDATION_STRICT)
+
+	if updatedRecords[1].Player != established[1] {
+		t.Errorf("Established player 1 did not use the regular system constant")
+	}
+	for _, id := range []int{2, 3, 4} {
+		if updatedRecords[id].Player != placed[id] {
+			t.Errorf("Provisional player %v did not use the placement system constant", id)
+		}
+	}
+}
+
+func TestProvisionalDeviationThreshold(t *testing.T) {
+	provisional := DefaultProvisionalSettings()
+
+	if !provisional.IsProvisional(NewDefaultGlicko2Player(), 100) {
+		t.Errorf("A new player should be provisional by deviation")
+	}
+	established := ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{Rating: 1500, RatingDeviation: 60})
+	if provisional.IsProvisional(established, 0) {
+		t.Errorf("A player with a deviation of 60 should not be provisional")
+	}
+	if (ProvisionalSettings{}).IsProvisional(NewDefaultGlicko2Player(), 0) {
+		t.Errorf("No player should be provisional without any criteria")
+	}
+}
+
+func TestProvisionalSettingsValidate(t *testing.T) {
+	for _, provisional := range []ProvisionalSettings{
+		{DeviationThreshold: -1},
+		{MinGames: -1},
+		{PlacementSystemConstant: -0.5},
+	} {
+		if err := provisional.Validate(); !errors.Is(err, ErrInvalidSettings) {
+			t.Errorf("Expected %+v to be invalid, got %v", provisional, err)
+		}
+		if _, err := ProvisionalPeriodCalculatorWithSettings(glicko2DefaultSettings, provisional)(getExampleRecords(provisional, 0), nil); err == nil {
+			t.Errorf("Calculating a period with %+v did not cause an error", provisional)
+		}
+	}
+}
+
+func TestLeaderboardProvisional(t *testing.T) {
+	options := DefaultLeaderboardOptions[int]()
+	options.Provisional = ProvisionalSettings{MinGames: 5}
+	options.GamesPlayed = map[int]int{1: 10, 2: 1, 3: 10, 4: 10, 5: 10}
+
+	leaderboard, err := BuildLeaderboard(getLeaderboardPlayers(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range leaderboard.Entries {
+		if entry.Provisional != (entry.ID == 2) {
+			t.Errorf("Expected only player 2 to be provisional, got %+v", entry)
+		}
+	}
+
+	options.ExcludeProvisional = true
+	leaderboard, err = BuildLeaderboard(getLeaderboardPlayers(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaderboard.Eligible != 4 || leaderboard.Entries[0].ID != 3 {
+		t.Errorf("Expected provisional player 2 to be excluded, got %+v", leaderboard.Entries)
+	}
+}
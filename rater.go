@@ -0,0 +1,200 @@
+package glicko2go
+
+// RatingSystem is implemented by types that can rate players within the Glicko 2 system.
+// Services can depend on this interface instead of a concrete Rater, allowing it to be mocked.
+type RatingSystem interface {
+	// Settings returns the constants used for every calculation.
+	Settings() Glicko2AlgorithmSettings
+	// UpdatePlayer calculates a player's stats after a period containing `periodGames`.
+	UpdatePlayer(player Glicko2Player, periodGames []Glicko2MatchForPlayer) (Glicko2Player, error)
+	// UpdatePeriod calculates every player's stats after a period, including those who have not played.
+	UpdatePeriod(players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error)
+	// ExpectedScore returns the expected outcome of `player` against `opponent`.
+	ExpectedScore(player Glicko2Player, opponent Glicko2Player) float64
+}
+
+// Rater calculates rating updates using a fixed set of Glicko2AlgorithmSettings.
+// It is safe for concurrent use, as it holds no state other than its settings.
+type Rater struct {
+	settings Glicko2AlgorithmSettings
+}
+
+var _ RatingSystem = (*Rater)(nil)
+
+// NewRater creates a Rater using `settings`. The settings are validated on every update,
+// so call Glicko2AlgorithmSettings.Validate beforehand to catch invalid settings early.
+func NewRater(settings Glicko2AlgorithmSettings) *Rater {
+	return &Rater{settings: settings}
+}
+
+// NewDefaultRater creates a Rater using the same settings as RawPlayerUpdaterWithDefaultSettings.
+func NewDefaultRater() *Rater {
+	return NewRater(glicko2DefaultSettings)
+}
+
+// Settings returns the constants used by the Rater.
+func (r *Rater) Settings() Glicko2AlgorithmSettings {
+	return r.settings
+}
+
+// UpdatePlayerRaw is equivalent to UpdatePlayerFromMatches, returning the result as a Glicko2Player.
+//
+// Matches are derived from `opponentRatings`, `opponentDeviations` and `gameOutcomes` using the order of their contents.
+func (r *Rater) UpdatePlayerRaw(playerRating float64, playerDeviation float64, playerVolatility float64,
+	opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64) (Glicko2Player, error) {
+
+	newRating, newDeviation, newVolatility, err := UpdatePlayerFromMatches(playerRating, playerDeviation, playerVolatility, opponentRatings, opponentDeviations, gameOutcomes, r.settings)
+
+	if err != nil {
+		return Glicko2Player{}, err
+	}
+
+	return Glicko2Player{
+		GlickoPlayer: GlickoPlayer{
+			Rating:          newRating,
+			RatingDeviation: newDeviation,
+		},
+		RatingVolatility: newVolatility,
+	}, nil
+}
+
+// UpdatePlayerAgainst calculates a player's stats after a period, where `opponents` and `gameOutcomes` are paired by index.
+func (r *Rater) UpdatePlayerAgainst(player Glicko2Player, opponents []Glicko2Player, gameOutcomes []float64) (Glicko2Player, error) {
+	opponentRatings := make([]float64, 0, len(opponents))
+	opponentDeviations := make([]float64, 0, len(opponents))
+
+	for _, opponent := range opponents {
+		opponentRatings = append(opponentRatings, opponent.Rating)
+		opponentDeviations = append(opponentDeviations, opponent.RatingDeviation)
+	}
+
+	return r.UpdatePlayerRaw(player.Rating, player.RatingDeviation, player.RatingVolatility, opponentRatings, opponentDeviations, gameOutcomes)
+}
+
+// UpdatePlayer calculates a player's stats after a period containing `periodGames`.
+func (r *Rater) UpdatePlayer(player Glicko2Player, periodGames []Glicko2MatchForPlayer) (Glicko2Player, error) {
+	return r.UpdatePlayerAfterElapsed(player, periodGames, 1)
+}
+
+// UpdatePlayerAfterElapsed is equivalent to Rater.UpdatePlayer, but grows the player's deviation by `elapsedPeriods`
+// rather than a single period. See UpdatePlayerFromMatchesAfterElapsed.
+func (r *Rater) UpdatePlayerAfterElapsed(player Glicko2Player, periodGames []Glicko2MatchForPlayer, elapsedPeriods float64) (Glicko2Player, error) {
+	opponentRatings := make([]float64, 0, len(periodGames))
+	opponentDeviations := make([]float64, 0, len(periodGames))
+	// TODO: Be more consistent with usage of game result vs outcome
+	gameResults := make([]float64, 0, len(periodGames))
+
+	for _, game := range periodGames {
+		opponentRatings = append(opponentRatings, game.Opponent.Rating)
+		opponentDeviations = append(opponentDeviations, game.Opponent.RatingDeviation)
+		gameResults = append(gameResults, game.Result)
+	}
+
+	newRating, newDeviation, newVolatility, err := UpdatePlayerFromMatchesAfterElapsed(player.Rating, player.RatingDeviation, player.RatingVolatility,
+		opponentRatings, opponentDeviations, gameResults, elapsedPeriods, r.settings)
+
+	if err != nil {
+		return Glicko2Player{}, err
+	}
+
+	return Glicko2Player{
+		GlickoPlayer: GlickoPlayer{
+			Rating:          newRating,
+			RatingDeviation: newDeviation,
+		},
+		RatingVolatility: newVolatility,
+	}, nil
+}
+
+// UpdatePeriod calculates every player's stats after a period using MATCH_VALIDATION_STRICT.
+// See UpdatePeriodWithPolicy for details.
+func (r *Rater) UpdatePeriod(players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error) {
+	updatedPlayers, _, err := r.UpdatePeriodWithPolicy(players, matches, MATCH_VALIDATION_STRICT)
+	return updatedPlayers, err
+}
+
+// UpdatePeriodWithPolicy calculates every player's stats after a period, validating `matches` against `players` first.
+//
+// With MATCH_VALIDATION_STRICT, any invalid match causes a *MatchValidationError to be returned and no players are updated.
+// With MATCH_VALIDATION_LENIENT, invalid matches are skipped and returned as a report alongside the updated players.
+//
+// To use player IDs other than `int`, see UpdatePeriodByID.
+func (r *Rater) UpdatePeriodWithPolicy(players map[int]Glicko2Player, matches []Glicko2MatchByID, policy MatchValidationPolicy) (map[int]Glicko2Player, []MatchIssue[int], error) {
+	return UpdatePeriodByID(r, players, matches, policy)
+}
+
+// UpdatePeriodByID is the generic form of Rater.UpdatePeriodWithPolicy, allowing players to be identified by any comparable type.
+func UpdatePeriodByID[ID comparable](r *Rater, players map[ID]Glicko2Player, matches []Glicko2Match[ID], policy MatchValidationPolicy) (map[ID]Glicko2Player, []MatchIssue[ID], error) {
+	return UpdatePeriodByIDAfterElapsed(r, players, matches, policy, nil)
+}
+
+// UpdatePeriodByIDAfterElapsed is equivalent to UpdatePeriodByID, but grows each player's deviation by the number of periods
+// within `elapsedPeriods` rather than a single period, including fractions of a period. See UpdatePlayerFromMatchesAfterElapsed.
+// Players missing from `elapsedPeriods` are treated as if a single period has elapsed.
+func UpdatePeriodByIDAfterElapsed[ID comparable](r *Rater, players map[ID]Glicko2Player, matches []Glicko2Match[ID], policy MatchValidationPolicy,
+	elapsedPeriods map[ID]float64) (map[ID]Glicko2Player, []MatchIssue[ID], error) {
+
+	newMatchLists, issues, err := buildPeriodMatchLists(players, matches, policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updatedPlayers := make(map[ID]Glicko2Player, len(players))
+
+	for playerID, player := range players {
+
+		playerElapsedPeriods, ok := elapsedPeriods[playerID]
+		if !ok {
+			playerElapsedPeriods = 1
+		}
+
+		updatedPlayer, err := r.UpdatePlayerAfterElapsed(player, newMatchLists[playerID], playerElapsedPeriods)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		updatedPlayers[playerID] = updatedPlayer
+
+	}
+
+	return updatedPlayers, issues, nil
+}
+
+// buildPeriodMatchLists validates `matches` according to `policy`, then splits them into a list of matches for each player.
+// The order of each player's matches follows the order of `matches`.
+func buildPeriodMatchLists[ID comparable](players map[ID]Glicko2Player, matches []Glicko2Match[ID], policy MatchValidationPolicy) (map[ID][]Glicko2MatchForPlayer, []MatchIssue[ID], error) {
+
+	issues := ValidateMatches(players, matches)
+	if len(issues) > 0 {
+		if policy != MATCH_VALIDATION_LENIENT {
+			return nil, nil, &MatchValidationError[ID]{Issues: issues}
+		}
+		matches = filterInvalidMatches(matches, issues)
+	}
+
+	newMatchLists := make(map[ID][]Glicko2MatchForPlayer)
+
+	for _, match := range matches {
+		newMatchLists[match.Player1ID] = append(newMatchLists[match.Player1ID], Glicko2MatchForPlayer{
+			Opponent: players[match.Player2ID],
+			Result:   match.Result,
+		},
+		)
+
+		newMatchLists[match.Player2ID] = append(newMatchLists[match.Player2ID], Glicko2MatchForPlayer{
+			Opponent: players[match.Player1ID],
+			Result:   1 - match.Result,
+		},
+		)
+
+	}
+
+	return newMatchLists, issues, nil
+}
+
+// ExpectedScore returns the expected outcome of `player` against `opponent` (AKA `E(µ, µj, φj)` from step 3),
+// where GAME_OUTCOME_WIN is a certain win and GAME_OUTCOME_LOSS is a certain loss.
+func (r *Rater) ExpectedScore(player Glicko2Player, opponent Glicko2Player) float64 {
+	return step3E(player.Rating, opponent.Rating, opponent.RatingDeviation)
+}
@@ -0,0 +1,118 @@
+package glicko2go
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Glicko2Replay is the outcome of replaying a sequence of periods with a single set of settings.
+type Glicko2Replay[ID comparable] struct {
+	Settings Glicko2AlgorithmSettings
+	// InitialPlayers holds every player before the first period. It is shared between every replay from the same call.
+	InitialPlayers map[ID]Glicko2Player
+	// Players holds every player after the final period. It is the same map as the final entry of Periods,
+	// or InitialPlayers if there were no periods.
+	Players map[ID]Glicko2Player
+	// Periods holds every player after each period, in the order the periods were replayed.
+	Periods []map[ID]Glicko2Player
+}
+
+// PlayersBefore returns every player before the period at `index` was replayed, which are InitialPlayers when `index` is 0.
+func (r Glicko2Replay[ID]) PlayersBefore(index int) map[ID]Glicko2Player {
+	if index == 0 {
+		return r.InitialPlayers
+	}
+	return r.Periods[index-1]
+}
+
+// ReplayPeriods rates `players` from scratch through every period of `periods` in order, once for each of `variants`.
+// A replay is returned for each variant, in the same order as `variants`.
+//
+// Each period is applied to every variant before moving onto the next, so the periods are only iterated over once.
+// Any invalid match or settings fails the whole replay.
+func ReplayPeriods[ID comparable](players map[ID]Glicko2Player, periods [][]Glicko2Match[ID], variants ...Glicko2AlgorithmSettings) ([]Glicko2Replay[ID], error) {
+	return ReplayPeriodSeq(players, slices.Values(periods), variants...)
+}
+
+// ReplayPeriodSeq is equivalent to ReplayPeriods, but reads periods from `periods` as they are needed,
+// so the periods of a large archive do not need to be held in memory. Players are still kept after every period
+// of every variant; use ReplayPeriodSeqFunc to inspect each period without keeping them.
+func ReplayPeriodSeq[ID comparable](players map[ID]Glicko2Player, periods iter.Seq[[]Glicko2Match[ID]], variants ...Glicko2AlgorithmSettings) ([]Glicko2Replay[ID], error) {
+	var periodPlayers [][]map[ID]Glicko2Player
+	replays, err := ReplayPeriodSeqFunc(players, periods, func(period ReplayedPeriod[ID]) error {
+		if period.Variant == 0 {
+			periodPlayers = append(periodPlayers, make([]map[ID]Glicko2Player, len(variants)))
+		}
+		periodPlayers[period.Index][period.Variant] = period.After
+		return nil
+	}, variants...)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range replays {
+		replays[idx].Periods = make([]map[ID]Glicko2Player, len(periodPlayers))
+		for periodIdx := range periodPlayers {
+			replays[idx].Periods[periodIdx] = periodPlayers[periodIdx][idx]
+		}
+	}
+	return replays, nil
+}
+
+// ReplayedPeriod is a single period applied to a single variant, as passed to the callback of ReplayPeriodSeqFunc.
+type ReplayedPeriod[ID comparable] struct {
+	// Index is the position of the period within the replayed sequence.
+	Index int
+	// Variant is the index of the settings the period was replayed with.
+	Variant int
+	Matches []Glicko2Match[ID]
+	// Before holds every player before the period, and After holds every player after it.
+	// Both are shared with the replay, so callers must not mutate them, although they may be kept after the callback returns.
+	Before map[ID]Glicko2Player
+	After  map[ID]Glicko2Player
+}
+
+// ReplayPeriodSeqFunc is equivalent to ReplayPeriodSeq, but only keeps the latest players of each variant.
+// Instead, `onPeriod` is called after each period is applied to each variant, and any error it returns stops the replay.
+// If `onPeriod` is nil, periods are replayed without being reported. The returned replays have no Periods.
+func ReplayPeriodSeqFunc[ID comparable](
+	players map[ID]Glicko2Player,
+	periods iter.Seq[[]Glicko2Match[ID]],
+	onPeriod func(period ReplayedPeriod[ID]) error,
+	variants ...Glicko2AlgorithmSettings) ([]Glicko2Replay[ID], error) {
+
+	initialPlayers := maps.Clone(players)
+	replays := make([]Glicko2Replay[ID], len(variants))
+	raters := make([]*Rater, len(variants))
+
+	for idx, settings := range variants {
+		if err := settings.Validate(); err != nil {
+			return nil, fmt.Errorf("variant %v: %w", idx, err)
+		}
+		replays[idx] = Glicko2Replay[ID]{Settings: settings, InitialPlayers: initialPlayers, Players: initialPlayers}
+		raters[idx] = NewRater(settings)
+	}
+
+	periodIdx := 0
+	for matches := range periods {
+		for idx := range replays {
+			updatedPlayers, _, err := UpdatePeriodByID(raters[idx], replays[idx].Players, matches, MATCH_VALIDATION_STRICT)
+			if err != nil {
+				return nil, fmt.Errorf("variant %v, period %v: %w", idx, periodIdx, err)
+			}
+
+			period := ReplayedPeriod[ID]{Index: periodIdx, Variant: idx, Matches: matches, Before: replays[idx].Players, After: updatedPlayers}
+			if onPeriod != nil {
+				if err := onPeriod(period); err != nil {
+					return nil, fmt.Errorf("variant %v, period %v: %w", idx, periodIdx, err)
+				}
+			}
+			replays[idx].Players = updatedPlayers
+		}
+		periodIdx++
+	}
+
+	return replays, nil
+}
@@ -0,0 +1,140 @@
+package glicko2go
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// TestReplayPeriodsMatchesPeriodCalculator checks that every variant matches running its own period calculator in order.
+func TestReplayPeriodsMatchesPeriodCalculator(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+	periods := [][]Glicko2MatchByID{matchList, nil, matchList}
+
+	variants := []Glicko2AlgorithmSettings{
+		{SystemConstant: GLICKO2_LOW_SYSTEM_CONSTANT, ConvergenceTolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE},
+		{SystemConstant: GLICKO2_DEFAULT_SYSTEM_CONSTANT, ConvergenceTolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE},
+		{SystemConstant: GLICKO2_HIGH_SYSTEM_CONSTANT, ConvergenceTolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE},
+	}
+
+	replays, err := ReplayPeriods(players, periods, variants...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replays) != len(variants) {
+		t.Fatalf("Expected %v replays, got %v", len(variants), len(replays))
+	}
+
+	for variantIdx, replay := range replays {
+		if replay.Settings != variants[variantIdx] {
+			t.Errorf("Replay %v used settings %+v, expected %+v", variantIdx, replay.Settings, variants[variantIdx])
+		}
+		if len(replay.Periods) != len(periods) {
+			t.Fatalf("Replay %v has %v periods, expected %v", variantIdx, len(replay.Periods), len(periods))
+		}
+
+		periodCalculator := PeriodCalculatorWithSettings(variants[variantIdx])
+		expectedPlayers := players
+		for periodIdx, matches := range periods {
+			if !maps.Equal(replay.PlayersBefore(periodIdx), expectedPlayers) {
+				t.Errorf("Replay %v had unexpected players before period %v", variantIdx, periodIdx)
+			}
+
+			expectedPlayers, err = periodCalculator(expectedPlayers, matches)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !maps.Equal(replay.Periods[periodIdx], expectedPlayers) {
+				t.Errorf("Replay %v had unexpected players after period %v", variantIdx, periodIdx)
+			}
+		}
+		if !maps.Equal(replay.Players, expectedPlayers) {
+			t.Errorf("Replay %v had unexpected final players", variantIdx)
+		}
+	}
+
+	if replays[0].Players[1].RatingVolatility == replays[2].Players[1].RatingVolatility {
+		t.Errorf("Different system constants should produce different volatilities")
+	}
+}
+
+func TestReplayPeriodsErrors(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+
+	if _, err := ReplayPeriods(players, [][]Glicko2MatchByID{matchList}, glicko2DefaultSettings, Glicko2AlgorithmSettings{}); err == nil {
+		t.Errorf("Invalid settings did not cause an error")
+	}
+
+	invalidPeriod := append(slices.Clone(matchList), Glicko2MatchByID{Player1ID: 1, Player2ID: 10, Result: GAME_OUTCOME_WIN})
+	if _, err := ReplayPeriods(players, [][]Glicko2MatchByID{matchList, invalidPeriod}, glicko2DefaultSettings); err == nil {
+		t.Errorf("An invalid match did not cause an error")
+	}
+}
+
+// TestReplayPeriodSeqFunc checks that each period is passed to the callback in order, without being kept in the replay.
+func TestReplayPeriodSeqFunc(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+	periods := [][]Glicko2MatchByID{matchList, nil, matchList}
+	variants := []Glicko2AlgorithmSettings{
+		{SystemConstant: GLICKO2_LOW_SYSTEM_CONSTANT, ConvergenceTolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE},
+		{SystemConstant: GLICKO2_HIGH_SYSTEM_CONSTANT, ConvergenceTolerance: GLICKO2_DEFAULT_CONVERGENCE_TOLERANCE},
+	}
+
+	expectedReplays, err := ReplayPeriods(players, periods, variants...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	replays, err := ReplayPeriodSeqFunc(players, slices.Values(periods), func(period ReplayedPeriod[int]) error {
+		if expectedIndex, expectedVariant := calls/len(variants), calls%len(variants); period.Index != expectedIndex || period.Variant != expectedVariant {
+			t.Errorf("Call %v was for period %v of variant %v, expected period %v of variant %v", calls, period.Index, period.Variant, expectedIndex, expectedVariant)
+		}
+		calls++
+
+		expectedReplay := expectedReplays[period.Variant]
+		if len(period.Matches) != len(periods[period.Index]) ||
+			!maps.Equal(period.Before, expectedReplay.PlayersBefore(period.Index)) ||
+			!maps.Equal(period.After, expectedReplay.Periods[period.Index]) {
+			t.Errorf("Period %v of variant %v does not match ReplayPeriods", period.Index, period.Variant)
+		}
+		return nil
+	}, variants...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != len(periods)*len(variants) {
+		t.Errorf("Expected %v calls, got %v", len(periods)*len(variants), calls)
+	}
+	for idx, replay := range replays {
+		if replay.Periods != nil {
+			t.Errorf("Replay %v kept %v periods", idx, len(replay.Periods))
+		}
+		if !maps.Equal(replay.Players, expectedReplays[idx].Players) {
+			t.Errorf("Replay %v had unexpected final players", idx)
+		}
+	}
+
+	errStop := errors.New("stop")
+	calls = 0
+	_, err = ReplayPeriodSeqFunc(players, slices.Values(periods), func(period ReplayedPeriod[int]) error {
+		calls++
+		return errStop
+	}, variants...)
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("An error from the callback did not stop the replay: %v after %v calls", err, calls)
+	}
+
+	// Without a callback, only the latest players are kept
+	replays, err = ReplayPeriodSeqFunc(players, slices.Values(periods), nil, variants...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx, replay := range replays {
+		if !maps.Equal(replay.Players, expectedReplays[idx].Players) {
+			t.Errorf("Replay %v without a callback had unexpected final players", idx)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package glicko2go
+
+const (
+	// GLICKO_SCALE_CENTRE is the rating at the centre of the standard Glicko scale, equivalent to a Glicko 2 rating of 0.
+	GLICKO_SCALE_CENTRE float64 = 1500
+	// GLICKO_SCALE_FACTOR is the conversion factor between the standard Glicko scale and the Glicko 2 scale, as described in step 2.
+	GLICKO_SCALE_FACTOR float64 = 173.7178
+)
+
+// Scale Represents a display scale for ratings and deviations, which are converted to and from the Glicko 2 scale.
+// The standard Glicko scale is used by the package's conversion functions, and can be created via NewStandardScale.
+type Scale struct {
+	// Centre is the rating on this scale that is equivalent to a Glicko 2 rating of 0.
+	Centre float64
+	// Factor is the number of rating points on this scale that is equivalent to a single point on the Glicko 2 scale.
+	Factor float64
+
+	// DefaultRating, DefaultDeviation and DefaultVolatility are used to create previously unrated players on this scale.
+	DefaultRating     float64
+	DefaultDeviation  float64
+	DefaultVolatility float64
+}
+
+// NewStandardScale creates the standard Glicko scale, using the values defined in steps 1 and 2.
+func NewStandardScale() Scale {
+	return Scale{
+		Centre:            GLICKO_SCALE_CENTRE,
+		Factor:            GLICKO_SCALE_FACTOR,
+		DefaultRating:     GLICKO_DEFAULT_PLAYER_RATING,
+		DefaultDeviation:  GLICKO_DEFAULT_PLAYER_DEVIATION,
+		DefaultVolatility: GLICKO2_DEFAULT_PLAYER_VOLATILITY,
+	}
+}
+
+// NewScale creates a Scale centred at `centre`, where `factor` rating points are equivalent to a single point on the Glicko 2 scale.
+// Default players are rated at `centre`, with the same deviation and volatility as on the standard scale once converted.
+func NewScale(centre float64, factor float64) Scale {
+	return Scale{
+		Centre:            centre,
+		Factor:            factor,
+		DefaultRating:     centre,
+		DefaultDeviation:  GLICKO_DEFAULT_PLAYER_DEVIATION / GLICKO_SCALE_FACTOR * factor,
+		DefaultVolatility: GLICKO2_DEFAULT_PLAYER_VOLATILITY,
+	}
+}
+
+var standardScale = NewStandardScale()
+
+func (s Scale) RatingToGlicko2(rating float64) float64 {
+	return (rating - s.Centre) / s.Factor
+}
+
+func (s Scale) Glicko2RatingToScale(g2Rating float64) float64 {
+	return g2Rating*s.Factor + s.Centre
+}
+
+func (s Scale) DeviationToGlicko2(deviation float64) float64 {
+	return deviation / s.Factor
+}
+
+func (s Scale) Glicko2DeviationToScale(g2Deviation float64) float64 {
+	return g2Deviation * s.Factor
+}
+
+// ConvertToGlicko2 converts `gp`, whose values are on this scale, to a Glicko2Player with the given volatility.
+func (s Scale) ConvertToGlicko2(gp GlickoPlayer, volatility float64) Glicko2Player {
+	return Glicko2Player{
+		GlickoPlayer: GlickoPlayer{
+			Rating:          s.RatingToGlicko2(gp.Rating),
+			RatingDeviation: s.DeviationToGlicko2(gp.RatingDeviation),
+		},
+		RatingVolatility: volatility,
+	}
+}
+
+// ConvertToGlicko2WithDefaultVolatility converts `gp` using the scale's DefaultVolatility.
+func (s Scale) ConvertToGlicko2WithDefaultVolatility(gp GlickoPlayer) Glicko2Player {
+	return s.ConvertToGlicko2(gp, s.DefaultVolatility)
+}
+
+// ConvertFromGlicko2 converts `g2p` to a GlickoPlayer whose values are on this scale.
+func (s Scale) ConvertFromGlicko2(g2p Glicko2Player) GlickoPlayer {
+	return GlickoPlayer{
+		Rating:          s.Glicko2RatingToScale(g2p.Rating),
+		RatingDeviation: s.Glicko2DeviationToScale(g2p.RatingDeviation),
+	}
+}
+
+// NewDefaultGlickoPlayer creates a GlickoPlayer on this scale, representing a previously unrated player.
+func (s Scale) NewDefaultGlickoPlayer() GlickoPlayer {
+	return GlickoPlayer{
+		Rating:          s.DefaultRating,
+		RatingDeviation: s.DefaultDeviation,
+	}
+}
+
+// NewDefaultGlicko2Player creates a Glicko2Player representing a previously unrated player on this scale.
+func (s Scale) NewDefaultGlicko2Player() Glicko2Player {
+	return s.ConvertToGlicko2WithDefaultVolatility(s.NewDefaultGlickoPlayer())
+}
@@ -0,0 +1,146 @@
+package glicko2go
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PeriodSchedule splits time into consecutive rating periods, beginning at Start.
+// Use DailyPeriodSchedule, WeeklyPeriodSchedule or FixedLengthPeriodSchedule to create one.
+type PeriodSchedule struct {
+	Start time.Time
+	// next returns the start of the period following the one starting at `periodStart`.
+	next func(periodStart time.Time) time.Time
+}
+
+// DailyPeriodSchedule creates a PeriodSchedule of calendar days, beginning at `start`.
+// Days follow the calendar of `start`'s location, so they may not be exactly 24 hours long around daylight saving changes.
+func DailyPeriodSchedule(start time.Time) PeriodSchedule {
+	return PeriodSchedule{
+		Start: start,
+		next: func(periodStart time.Time) time.Time {
+			return periodStart.AddDate(0, 0, 1)
+		},
+	}
+}
+
+// WeeklyPeriodSchedule creates a PeriodSchedule of calendar weeks, beginning at `start`.
+func WeeklyPeriodSchedule(start time.Time) PeriodSchedule {
+	return PeriodSchedule{
+		Start: start,
+		next: func(periodStart time.Time) time.Time {
+			return periodStart.AddDate(0, 0, 7)
+		},
+	}
+}
+
+// FixedLengthPeriodSchedule creates a PeriodSchedule where every period is exactly `length` long, beginning at `start`.
+func FixedLengthPeriodSchedule(start time.Time, length time.Duration) PeriodSchedule {
+	return PeriodSchedule{
+		Start: start,
+		next: func(periodStart time.Time) time.Time {
+			return periodStart.Add(length)
+		},
+	}
+}
+
+// Glicko2Period holds the matches played within a single rating period, covering [Start, End).
+type Glicko2Period[ID comparable] struct {
+	Start   time.Time
+	End     time.Time
+	Matches []Glicko2Match[ID]
+}
+
+// SplitMatchesIntoPeriods places every match into a period of `schedule` using its PlayedAt time.
+//
+// Periods are returned in order and cover [schedule.Start, end), including periods that contain no matches.
+// The final period ends at or after `end`. Matches keep their relative order within each period.
+// An error is returned if a match was played outside of [schedule.Start, end).
+func SplitMatchesIntoPeriods[ID comparable](schedule PeriodSchedule, matches []Glicko2Match[ID], end time.Time) ([]Glicko2Period[ID], error) {
+	if schedule.next == nil {
+		return nil, errors.New("period schedule must be created with DailyPeriodSchedule, WeeklyPeriodSchedule or FixedLengthPeriodSchedule")
+	}
+
+	var periods []Glicko2Period[ID]
+	for periodStart := schedule.Start; periodStart.Before(end); {
+		periodEnd := schedule.next(periodStart)
+		if !periodEnd.After(periodStart) {
+			return nil, fmt.Errorf("period schedule must move forwards in time, got a period from %v to %v", periodStart, periodEnd)
+		}
+		periods = append(periods, Glicko2Period[ID]{Start: periodStart, End: periodEnd})
+		periodStart = periodEnd
+	}
+
+	for idx, match := range matches {
+		if match.PlayedAt.Before(schedule.Start) || !match.PlayedAt.Before(end) {
+			return nil, fmt.Errorf("match %v was played at %v, which is outside of the schedule from %v to %v", idx, match.PlayedAt, schedule.Start, end)
+		}
+
+		periodIdx := sort.Search(len(periods), func(i int) bool {
+			return periods[i].End.After(match.PlayedAt)
+		})
+		periods[periodIdx].Matches = append(periods[periodIdx].Matches, match)
+	}
+
+	return periods, nil
+}
+
+// GenericScheduledPeriodCalculatorWithSettings returns a function that splits `matches` into the periods of `schedule`
+// up until `end`, then calculates each period in order via GenericPeriodCalculatorWithSettings.
+// Players' deviations grow during periods in which they do not play, including periods with no matches at all.
+//
+// The players after the final period are returned alongside the periods used.
+func GenericScheduledPeriodCalculatorWithSettings[ID comparable](settings Glicko2AlgorithmSettings, schedule PeriodSchedule) func(
+	players map[ID]Glicko2Player, matches []Glicko2Match[ID], end time.Time) (map[ID]Glicko2Player, []Glicko2Period[ID], error) {
+	return GenericScheduledPeriodCalculatorWithHistory[ID](settings, schedule, nil)
+}
+
+// GenericScheduledPeriodCalculatorWithHistory is equivalent to GenericScheduledPeriodCalculatorWithSettings,
+// but records the players after each period within `history`. If `history` is nil, nothing is recorded.
+//
+// Periods are only recorded once every period has been calculated, so `history` is left unchanged if any period fails.
+func GenericScheduledPeriodCalculatorWithHistory[ID comparable](settings Glicko2AlgorithmSettings, schedule PeriodSchedule, history *RatingHistory[ID]) func(
+	players map[ID]Glicko2Player, matches []Glicko2Match[ID], end time.Time) (map[ID]Glicko2Player, []Glicko2Period[ID], error) {
+
+	periodCalculator := GenericPeriodCalculatorWithSettings[ID](settings)
+
+	return func(players map[ID]Glicko2Player, matches []Glicko2Match[ID], end time.Time) (map[ID]Glicko2Player, []Glicko2Period[ID], error) {
+		periods, err := SplitMatchesIntoPeriods(schedule, matches, end)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		periodPlayers := make([]map[ID]Glicko2Player, 0, len(periods))
+		for _, period := range periods {
+			players, err = periodCalculator(players, period.Matches)
+			if err != nil {
+				return nil, nil, fmt.Errorf("period from %v to %v: %w", period.Start, period.End, err)
+			}
+			periodPlayers = append(periodPlayers, players)
+		}
+
+		if history != nil {
+			for idx, period := range periods {
+				if _, err := history.Record(period.Start, period.End, periodPlayers[idx]); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+
+		return players, periods, nil
+	}
+}
+
+// ScheduledPeriodCalculatorWithSettings is GenericScheduledPeriodCalculatorWithSettings for `int` player IDs.
+func ScheduledPeriodCalculatorWithSettings(settings Glicko2AlgorithmSettings, schedule PeriodSchedule) func(
+	players map[int]Glicko2Player, matches []Glicko2MatchByID, end time.Time) (map[int]Glicko2Player, []Glicko2Period[int], error) {
+	return GenericScheduledPeriodCalculatorWithSettings[int](settings, schedule)
+}
+
+// ScheduledPeriodCalculatorWithHistory is GenericScheduledPeriodCalculatorWithHistory for `int` player IDs.
+func ScheduledPeriodCalculatorWithHistory(settings Glicko2AlgorithmSettings, schedule PeriodSchedule, history *RatingHistory[int]) func(
+	players map[int]Glicko2Player, matches []Glicko2MatchByID, end time.Time) (map[int]Glicko2Player, []Glicko2Period[int], error) {
+	return GenericScheduledPeriodCalculatorWithHistory[int](settings, schedule, history)
+}
@@ -0,0 +1,370 @@
+// Package server provides an optional HTTP rating service, backed by glicko2go.PeriodCalculatorWithSettings
+// and any glicko2go.Store.
+//
+// Endpoints:
+//
+//	PUT  /players/{id}      Create or replace a player
+//	GET  /players/{id}      Fetch a player
+//	POST /matches           Submit a JSON array of matches for the current period
+//	POST /periods           Close the current period, updating every player
+//	GET  /leaderboard       Fetch a page of players ranked by glicko2go.BuildLeaderboard, using `?k=` (by default
+//	                        GLICKO2_DEFAULT_LEADERBOARD_DEVIATION_MULTIPLIER), `?max_deviation=`, `?offset=` and `?limit=`
+//	GET  /expected-score    Query the win probability of `?player=` against `?opponent=`
+//
+// Players are encoded as with glicko2go.Glicko2Player, alongside their `id`. Add `?scale=glicko` to use the Glicko scale
+// for player requests and responses instead of the Glicko 2 scale. Players with a non-positive or non-finite deviation or volatility
+// are rejected with 400 Bad Request.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Too-Zestyy/glicko2go"
+)
+
+// MAX_REQUEST_BODY_BYTES limits the size of JSON request bodies. Larger bodies are rejected with 413 Request Entity Too Large.
+const MAX_REQUEST_BODY_BYTES = 1 << 20
+
+// Server is an http.Handler serving a rating service.
+type Server struct {
+	calculatePeriod func(players map[int]glicko2go.Glicko2Player, matches []glicko2go.Glicko2MatchByID) (map[int]glicko2go.Glicko2Player, error)
+	store           glicko2go.Store
+	mux             *http.ServeMux
+}
+
+// NewServer creates a Server rating players with `settings`, storing them within `store`.
+func NewServer(settings glicko2go.Glicko2AlgorithmSettings, store glicko2go.Store) (*Server, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		calculatePeriod: glicko2go.PeriodCalculatorWithSettings(settings),
+		store:           store,
+		mux:             http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("PUT /players/{id}", s.handleSetPlayer)
+	s.mux.HandleFunc("GET /players/{id}", s.handleGetPlayer)
+	s.mux.HandleFunc("POST /matches", s.handleSubmitMatches)
+	s.mux.HandleFunc("POST /periods", s.handleClosePeriod)
+	s.mux.HandleFunc("GET /leaderboard", s.handleLeaderboard)
+	s.mux.HandleFunc("GET /expected-score", s.handleExpectedScore)
+
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+//// Encoding
+
+// playerResponse is a player alongside their ID, on the scale requested.
+type playerResponse struct {
+	ID               int     `json:"id"`
+	Rating           float64 `json:"rating"`
+	RatingDeviation  float64 `json:"rating_deviation"`
+	RatingVolatility float64 `json:"rating_volatility"`
+}
+
+type playerRequest struct {
+	Rating           *float64 `json:"rating"`
+	RatingDeviation  *float64 `json:"rating_deviation"`
+	RatingVolatility *float64 `json:"rating_volatility"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// requestScale returns the scale requested via `?scale=`, where the Glicko 2 scale is the default.
+func requestScale(r *http.Request) (glicko2go.Scale, error) {
+	switch r.URL.Query().Get("scale") {
+	case "", "glicko2":
+		return glicko2go.NewScale(0, 1), nil
+	case "glicko":
+		return glicko2go.NewStandardScale(), nil
+	default:
+		return glicko2go.Scale{}, fmt.Errorf("unknown scale %q", r.URL.Query().Get("scale"))
+	}
+}
+
+func newPlayerResponse(id int, player glicko2go.Glicko2Player, scale glicko2go.Scale) playerResponse {
+	scaledPlayer := scale.ConvertFromGlicko2(player)
+	return playerResponse{
+		ID:               id,
+		Rating:           scaledPlayer.Rating,
+		RatingDeviation:  scaledPlayer.RatingDeviation,
+		RatingVolatility: player.RatingVolatility,
+	}
+}
+
+// decodeJSON decodes the body of `r` into `value`, reading at most MAX_REQUEST_BODY_BYTES.
+// If decoding fails, an error response is written and false is returned.
+func decodeJSON(w http.ResponseWriter, r *http.Request, value any) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MAX_REQUEST_BODY_BYTES)).Decode(value)
+	if err == nil {
+		return true
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %v bytes", maxBytesErr.Limit))
+	} else {
+		writeError(w, http.StatusBadRequest, err)
+	}
+	return false
+}
+
+// validate returns an error if any given value cannot describe a player: ratings must be finite,
+// while deviation and volatility must also be positive.
+func (request playerRequest) validate() error {
+	if request.Rating != nil && (math.IsNaN(*request.Rating) || math.IsInf(*request.Rating, 0)) {
+		return fmt.Errorf("rating must be finite, got %v", *request.Rating)
+	}
+	if request.RatingDeviation != nil && !(*request.RatingDeviation > 0 && !math.IsInf(*request.RatingDeviation, 1)) {
+		return fmt.Errorf("rating_deviation must be positive and finite, got %v", *request.RatingDeviation)
+	}
+	if request.RatingVolatility != nil && !(*request.RatingVolatility > 0 && !math.IsInf(*request.RatingVolatility, 1)) {
+		return fmt.Errorf("rating_volatility must be positive and finite, got %v", *request.RatingVolatility)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// intParam parses an integer from `value`, which is named `name` within errors.
+func intParam(value string, name string) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v %q", name, value)
+	}
+	return parsed, nil
+}
+
+// floatParam parses a finite number from `value`, which is named `name` within errors.
+func floatParam(value string, name string) (float64, error) {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		return 0, fmt.Errorf("invalid %v %q", name, value)
+	}
+	return parsed, nil
+}
+
+//// Handlers
+
+func (s *Server) handleSetPlayer(w http.ResponseWriter, r *http.Request) {
+	id, err := intParam(r.PathValue("id"), "player ID")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	scale, err := requestScale(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var request playerRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+	if err := request.validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Any value that is not given uses the scale's default
+	glickoPlayer := scale.NewDefaultGlickoPlayer()
+	if request.Rating != nil {
+		glickoPlayer.Rating = *request.Rating
+	}
+	if request.RatingDeviation != nil {
+		glickoPlayer.RatingDeviation = *request.RatingDeviation
+	}
+	player := scale.ConvertToGlicko2WithDefaultVolatility(glickoPlayer)
+	if request.RatingVolatility != nil {
+		player.RatingVolatility = *request.RatingVolatility
+	}
+
+	if err := s.store.SavePlayer(r.Context(), id, player); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newPlayerResponse(id, player, scale))
+}
+
+func (s *Server) handleGetPlayer(w http.ResponseWriter, r *http.Request) {
+	id, err := intParam(r.PathValue("id"), "player ID")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	scale, err := requestScale(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	player, ok, err := s.store.Player(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("player %v does not exist", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, newPlayerResponse(id, player, scale))
+}
+
+func (s *Server) handleSubmitMatches(w http.ResponseWriter, r *http.Request) {
+	var matches []glicko2go.Glicko2MatchByID
+	if !decodeJSON(w, r, &matches) {
+		return
+	}
+
+	// Players are never removed, so matches valid now remain valid when the period is committed
+	players, err := s.matchPlayers(r, matches)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if issues := glicko2go.ValidateMatches(players, matches); len(issues) > 0 {
+		writeError(w, http.StatusBadRequest, &glicko2go.MatchValidationError[int]{Issues: issues})
+		return
+	}
+
+	if err := s.store.AddMatches(r.Context(), matches); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]int{"accepted": len(matches)})
+}
+
+// matchPlayers looks up each player named by `matches`, so they can be validated without copying every stored player.
+// Players that do not exist are left out of the returned map.
+func (s *Server) matchPlayers(r *http.Request, matches []glicko2go.Glicko2MatchByID) (map[int]glicko2go.Glicko2Player, error) {
+	players := make(map[int]glicko2go.Glicko2Player)
+	checked := make(map[int]bool)
+	for _, match := range matches {
+		for _, id := range []int{match.Player1ID, match.Player2ID} {
+			if checked[id] {
+				continue
+			}
+			checked[id] = true
+
+			player, ok, err := s.store.Player(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				players[id] = player
+			}
+		}
+	}
+	return players, nil
+}
+
+func (s *Server) handleClosePeriod(w http.ResponseWriter, r *http.Request) {
+	matchCount := 0
+	updatedPlayers, err := s.store.CommitPeriod(r.Context(), func(players map[int]glicko2go.Glicko2Player, matches []glicko2go.Glicko2MatchByID) (map[int]glicko2go.Glicko2Player, error) {
+		matchCount = len(matches)
+		return s.calculatePeriod(players, matches)
+	})
+	if err != nil {
+		var validationErr *glicko2go.MatchValidationError[int]
+		if errors.As(err, &validationErr) {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"players": len(updatedPlayers), "matches": matchCount})
+}
+
+func (s *Server) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	scale, err := requestScale(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	options := glicko2go.DefaultLeaderboardOptions[int]()
+	options.Scale = &scale
+
+	query := r.URL.Query()
+	for name, value := range map[string]*float64{"k": &options.DeviationMultiplier, "max_deviation": &options.MaxDeviation} {
+		if param := query.Get(name); param != "" {
+			if *value, err = floatParam(param, name); err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+	}
+	for name, value := range map[string]*int{"offset": &options.Offset, "limit": &options.Limit} {
+		if param := query.Get(name); param != "" {
+			if *value, err = intParam(param, name); err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+	}
+
+	players, err := s.store.Players(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	leaderboard, err := glicko2go.BuildLeaderboard(players, options)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, leaderboard)
+}
+
+func (s *Server) handleExpectedScore(w http.ResponseWriter, r *http.Request) {
+	playerID, err := intParam(r.URL.Query().Get("player"), "player ID")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	opponentID, err := intParam(r.URL.Query().Get("opponent"), "opponent ID")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var players [2]glicko2go.Glicko2Player
+	for i, id := range []int{playerID, opponentID} {
+		player, ok, err := s.store.Player(r.Context(), id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("player %v does not exist", id))
+			return
+		}
+		players[i] = player
+	}
+
+	writeJSON(w, http.StatusOK, map[string]float64{"expected_score": players[0].WinProbability(players[1])})
+}
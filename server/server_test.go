@@ -0,0 +1,186 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Too-Zestyy/glicko2go"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	s, err := NewServer(glicko2go.Glicko2AlgorithmSettings{SystemConstant: 0.5, ConvergenceTolerance: 0.000001}, glicko2go.NewMemoryStore(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// doRequest sends a request to `ts`, checking its status and decoding its JSON response into `response` if not nil.
+func doRequest(t *testing.T, ts *httptest.Server, method string, path string, body string, wantStatus int, response any) {
+	t.Helper()
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != wantStatus {
+		var errRes errorResponse
+		json.NewDecoder(res.Body).Decode(&errRes)
+		t.Fatalf("%v %v: expected status %v, got %v (%v)", method, path, wantStatus, res.StatusCode, errRes.Error)
+	}
+	if response != nil {
+		if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestServerExample runs the example at https://www.glicko.net/glicko/glicko2.pdf through the HTTP API.
+func TestServerExample(t *testing.T) {
+	ts := newTestServer(t)
+
+	for path, body := range map[string]string{
+		"/players/1": `{"rating":1500,"rating_deviation":200}`,
+		"/players/2": `{"rating":1400,"rating_deviation":30}`,
+		"/players/3": `{"rating":1550,"rating_deviation":100}`,
+		"/players/4": `{"rating":1700,"rating_deviation":300}`,
+	} {
+		doRequest(t, ts, http.MethodPut, path+"?scale=glicko", body, http.StatusOK, nil)
+	}
+
+	doRequest(t, ts, http.MethodPost, "/matches",
+		`[{"player1_id":1,"player2_id":2,"result":1},{"player1_id":1,"player2_id":3,"result":0},{"player1_id":1,"player2_id":4,"result":0}]`,
+		http.StatusAccepted, nil)
+	doRequest(t, ts, http.MethodPost, "/periods", "", http.StatusOK, nil)
+
+	var player playerResponse
+	doRequest(t, ts, http.MethodGet, "/players/1?scale=glicko", "", http.StatusOK, &player)
+	if math.Abs(player.Rating-1464.06) > 0.01 || math.Abs(player.RatingDeviation-151.52) > 0.01 {
+		t.Errorf("Player 1 does not match the paper: rating %v, deviation %v", player.Rating, player.RatingDeviation)
+	}
+
+	var leaderboard glicko2go.Leaderboard[int]
+	doRequest(t, ts, http.MethodGet, "/leaderboard?k=0&limit=2", "", http.StatusOK, &leaderboard)
+	if len(leaderboard.Entries) != 2 || leaderboard.Entries[0].ID != 4 || leaderboard.Entries[1].ID != 3 {
+		t.Errorf("Unexpected leaderboard by rating: %+v", leaderboard)
+	}
+
+	// Player 4's deviation is the largest, so a conservative leaderboard drops them below players 3 and 2
+	doRequest(t, ts, http.MethodGet, "/leaderboard?k=2&scale=glicko", "", http.StatusOK, &leaderboard)
+	if len(leaderboard.Entries) != 4 || leaderboard.Entries[0].ID != 3 || leaderboard.Entries[2].ID != 4 {
+		t.Errorf("Unexpected conservative leaderboard: %+v", leaderboard)
+	}
+
+	var expected map[string]float64
+	doRequest(t, ts, http.MethodGet, "/expected-score?player=4&opponent=2", "", http.StatusOK, &expected)
+	if score := expected["expected_score"]; score <= 0.5 || score >= 1 {
+		t.Errorf("Player 4 should be favoured against player 2, got an expected score of %v", score)
+	}
+}
+
+// TestServerLeaderboardIsConservativeByDefault checks that a barely tested player does not top the leaderboard unless `?k=0` is given.
+func TestServerLeaderboardIsConservativeByDefault(t *testing.T) {
+	ts := newTestServer(t)
+	doRequest(t, ts, http.MethodPut, "/players/1?scale=glicko", `{"rating":1900,"rating_deviation":350}`, http.StatusOK, nil)
+	doRequest(t, ts, http.MethodPut, "/players/2?scale=glicko", `{"rating":1700,"rating_deviation":50}`, http.StatusOK, nil)
+
+	var leaderboard glicko2go.Leaderboard[int]
+	doRequest(t, ts, http.MethodGet, "/leaderboard?scale=glicko", "", http.StatusOK, &leaderboard)
+	if len(leaderboard.Entries) != 2 || leaderboard.Entries[0].ID != 2 || leaderboard.Entries[1].ID != 1 {
+		t.Fatalf("Expected player 2 to outrank player 1 by default, got %+v", leaderboard)
+	}
+	if score := leaderboard.Entries[0].Score; math.Abs(score-(1700-glicko2go.GLICKO2_DEFAULT_LEADERBOARD_DEVIATION_MULTIPLIER*50)) > 1e-9 {
+		t.Errorf("Expected player 2 to score 1600 on the Glicko scale, got %v", score)
+	}
+
+	doRequest(t, ts, http.MethodGet, "/leaderboard?k=0", "", http.StatusOK, &leaderboard)
+	if len(leaderboard.Entries) != 2 || leaderboard.Entries[0].ID != 1 {
+		t.Errorf("Expected player 1 to top a leaderboard by rating alone, got %+v", leaderboard)
+	}
+}
+
+// playersForbiddenStore fails the test if every player is fetched, which should only be needed to close a period.
+type playersForbiddenStore struct {
+	*glicko2go.MemoryStore
+	t *testing.T
+}
+
+func (s playersForbiddenStore) Players(ctx context.Context) (map[int]glicko2go.Glicko2Player, error) {
+	s.t.Errorf("Every player was fetched")
+	return s.MemoryStore.Players(ctx)
+}
+
+// TestServerSubmitMatchesLooksUpPlayersByID checks that submitting matches only looks up the players within them.
+func TestServerSubmitMatchesLooksUpPlayersByID(t *testing.T) {
+	players := map[int]glicko2go.Glicko2Player{1: glicko2go.NewDefaultGlicko2Player(), 2: glicko2go.NewDefaultGlicko2Player()}
+	s, err := NewServer(glicko2go.Glicko2AlgorithmSettings{SystemConstant: 0.5, ConvergenceTolerance: 0.000001},
+		playersForbiddenStore{MemoryStore: glicko2go.NewMemoryStore(players), t: t})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+
+	doRequest(t, ts, http.MethodPost, "/matches", `[{"player1_id":1,"player2_id":2,"result":1},{"player1_id":2,"player2_id":1,"result":0.5}]`, http.StatusAccepted, nil)
+	doRequest(t, ts, http.MethodPost, "/matches", `[{"player1_id":1,"player2_id":3,"result":1}]`, http.StatusBadRequest, nil)
+}
+
+func TestServerPeriodClearsPendingMatches(t *testing.T) {
+	ts := newTestServer(t)
+	doRequest(t, ts, http.MethodPut, "/players/1", `{}`, http.StatusOK, nil)
+	doRequest(t, ts, http.MethodPut, "/players/2", `{}`, http.StatusOK, nil)
+	doRequest(t, ts, http.MethodPost, "/matches", `[{"player1_id":1,"player2_id":2,"result":1}]`, http.StatusAccepted, nil)
+
+	var first, second map[string]int
+	doRequest(t, ts, http.MethodPost, "/periods", "", http.StatusOK, &first)
+	doRequest(t, ts, http.MethodPost, "/periods", "", http.StatusOK, &second)
+	if first["matches"] != 1 || second["matches"] != 0 {
+		t.Errorf("Expected matches to be used by a single period, got %v then %v", first["matches"], second["matches"])
+	}
+}
+
+func TestServerRejectsInvalidRequests(t *testing.T) {
+	ts := newTestServer(t)
+	doRequest(t, ts, http.MethodPut, "/players/1", `{}`, http.StatusOK, nil)
+
+	doRequest(t, ts, http.MethodPost, "/matches", `[{"player1_id":1,"player2_id":5,"result":1}]`, http.StatusBadRequest, nil)
+	doRequest(t, ts, http.MethodPost, "/matches", `not json`, http.StatusBadRequest, nil)
+	doRequest(t, ts, http.MethodGet, "/players/5", "", http.StatusNotFound, nil)
+	doRequest(t, ts, http.MethodGet, "/players/abc", "", http.StatusBadRequest, nil)
+	doRequest(t, ts, http.MethodGet, "/players/1?scale=elo", "", http.StatusBadRequest, nil)
+	doRequest(t, ts, http.MethodGet, "/expected-score?player=1&opponent=5", "", http.StatusNotFound, nil)
+	doRequest(t, ts, http.MethodGet, "/leaderboard?limit=-1", "", http.StatusBadRequest, nil)
+	doRequest(t, ts, http.MethodGet, "/leaderboard?k=abc", "", http.StatusBadRequest, nil)
+
+	for _, body := range []string{
+		`{"rating_deviation":0}`,
+		`{"rating_deviation":-30}`,
+		`{"rating_volatility":0}`,
+		`{"rating_volatility":-0.06}`,
+		`{"rating":1e400}`,
+	} {
+		doRequest(t, ts, http.MethodPut, "/players/2?scale=glicko", body, http.StatusBadRequest, nil)
+	}
+	doRequest(t, ts, http.MethodGet, "/players/2", "", http.StatusNotFound, nil)
+
+	oversizedBody := "[" + strings.Repeat(" ", MAX_REQUEST_BODY_BYTES) + "]"
+	doRequest(t, ts, http.MethodPost, "/matches", oversizedBody, http.StatusRequestEntityTooLarge, nil)
+	doRequest(t, ts, http.MethodPut, "/players/2", `{"rating":0`+strings.Repeat(" ", MAX_REQUEST_BODY_BYTES)+`}`, http.StatusRequestEntityTooLarge, nil)
+
+	if _, err := NewServer(glicko2go.Glicko2AlgorithmSettings{}, glicko2go.NewMemoryStore(nil)); err == nil {
+		t.Errorf("Invalid settings did not cause an error")
+	}
+}
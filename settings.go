@@ -0,0 +1,81 @@
+package glicko2go
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// GLICKO2_DEFAULT_MAX_ITERATIONS is the number of iterations the volatility solver uses when
+	// Glicko2AlgorithmSettings.MaxIterations is left as 0. Convergence normally takes fewer than 10.
+	GLICKO2_DEFAULT_MAX_ITERATIONS = 100
+	// GLICKO2_DEFAULT_MAX_DEVIATION is the deviation cap used when Glicko2AlgorithmSettings.MaxDeviation is left as 0.
+	// Equivalent to GLICKO_DEFAULT_PLAYER_DEVIATION on the Glicko 2 scale, so no player is less certain than an unrated one.
+	GLICKO2_DEFAULT_MAX_DEVIATION float64 = GLICKO_DEFAULT_PLAYER_DEVIATION / GLICKO_SCALE_FACTOR
+)
+
+var (
+	// ErrInvalidSettings is wrapped by every error returned from the Validate method of Glicko2AlgorithmSettings
+	// and GlickoAlgorithmSettings.
+	ErrInvalidSettings = errors.New("invalid algorithm settings")
+	// ErrVolatilityDidNotConverge is returned when the volatility solver (step 5) exceeds its maximum iterations.
+	ErrVolatilityDidNotConverge = errors.New("volatility did not converge within the maximum iterations")
+)
+
+// Validate checks that the settings can be used without the volatility solver looping forever.
+// Any returned error wraps ErrInvalidSettings.
+func (s Glicko2AlgorithmSettings) Validate() error {
+	if !(s.SystemConstant > 0) || math.IsInf(s.SystemConstant, 1) {
+		return fmt.Errorf("%w: system constant must be positive and finite, got %v", ErrInvalidSettings, s.SystemConstant)
+	}
+	if !(s.ConvergenceTolerance > 0) || math.IsInf(s.ConvergenceTolerance, 1) {
+		return fmt.Errorf("%w: convergence tolerance must be positive and finite, got %v", ErrInvalidSettings, s.ConvergenceTolerance)
+	}
+	if !(s.MaxDeviation >= 0) || math.IsInf(s.MaxDeviation, 1) {
+		return fmt.Errorf("%w: max deviation must be non-negative and finite, got %v", ErrInvalidSettings, s.MaxDeviation)
+	}
+	if s.MaxIterations < 0 {
+		return fmt.Errorf("%w: max iterations cannot be negative, got %v", ErrInvalidSettings, s.MaxIterations)
+	}
+	return nil
+}
+
+// maxIterations returns MaxIterations, or GLICKO2_DEFAULT_MAX_ITERATIONS if it has not been set.
+func (s Glicko2AlgorithmSettings) maxIterations() int {
+	if s.MaxIterations == 0 {
+		return GLICKO2_DEFAULT_MAX_ITERATIONS
+	}
+	return s.MaxIterations
+}
+
+// maxDeviation returns MaxDeviation, or GLICKO2_DEFAULT_MAX_DEVIATION if it has not been set.
+// If the cap is disabled, +Inf is returned.
+func (s Glicko2AlgorithmSettings) maxDeviation() float64 {
+	if s.DisableMaxDeviation {
+		return math.Inf(1)
+	}
+	if s.MaxDeviation == 0 {
+		return GLICKO2_DEFAULT_MAX_DEVIATION
+	}
+	return s.MaxDeviation
+}
+
+// Validate checks that the settings are usable. Any returned error wraps ErrInvalidSettings.
+func (s GlickoAlgorithmSettings) Validate() error {
+	if !(s.DeviationGrowthConstant >= 0) || math.IsInf(s.DeviationGrowthConstant, 1) {
+		return fmt.Errorf("%w: deviation growth constant must be non-negative and finite, got %v", ErrInvalidSettings, s.DeviationGrowthConstant)
+	}
+	if s.MaxDeviation < 0 || math.IsNaN(s.MaxDeviation) {
+		return fmt.Errorf("%w: max deviation cannot be negative, got %v", ErrInvalidSettings, s.MaxDeviation)
+	}
+	return nil
+}
+
+// maxDeviation returns MaxDeviation, or GLICKO_DEFAULT_PLAYER_DEVIATION if it has not been set.
+func (s GlickoAlgorithmSettings) maxDeviation() float64 {
+	if s.MaxDeviation == 0 {
+		return GLICKO_DEFAULT_PLAYER_DEVIATION
+	}
+	return s.MaxDeviation
+}
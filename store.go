@@ -0,0 +1,367 @@
+package glicko2go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Store holds players and the matches waiting for the next period. Implementations must be safe for concurrent use.
+type Store interface {
+	// Players returns every player.
+	Players(ctx context.Context) (map[int]Glicko2Player, error)
+	// Player returns a single player, and false if they do not exist.
+	Player(ctx context.Context, id int) (Glicko2Player, bool, error)
+	// SavePlayer creates or replaces a player.
+	SavePlayer(ctx context.Context, id int, player Glicko2Player) error
+	// PendingMatches returns the matches added since the last period was committed.
+	PendingMatches(ctx context.Context) ([]Glicko2MatchByID, error)
+	// AddMatches adds to the pending matches. Matches are not validated until the period is committed.
+	AddMatches(ctx context.Context, matches []Glicko2MatchByID) error
+	// CommitPeriod passes every player and pending match to `calculatePeriod`, such as a function from
+	// PeriodCalculatorWithSettings, then replaces every player with its result and clears the pending matches.
+	// The commit is atomic: if `calculatePeriod` or saving fails, the store is left unchanged.
+	CommitPeriod(ctx context.Context, calculatePeriod func(players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error)) (map[int]Glicko2Player, error)
+}
+
+// storeState is the data held by a Store.
+type storeState struct {
+	Players        map[int]Glicko2Player `json:"players"`
+	PendingMatches []Glicko2MatchByID    `json:"pending_matches"`
+}
+
+func newStoreState(players map[int]Glicko2Player) storeState {
+	storedPlayers := maps.Clone(players)
+	if storedPlayers == nil {
+		storedPlayers = make(map[int]Glicko2Player)
+	}
+	return storeState{Players: storedPlayers}
+}
+
+// commitPeriod returns the state after `calculatePeriod`, without modifying `s`.
+func (s storeState) commitPeriod(calculatePeriod func(players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error)) (storeState, error) {
+	updatedPlayers, err := calculatePeriod(maps.Clone(s.Players), slices.Clone(s.PendingMatches))
+	if err != nil {
+		return storeState{}, err
+	}
+	return newStoreState(updatedPlayers), nil
+}
+
+//// MemoryStore
+
+// MemoryStore is a Store held in memory, which is lost when the process exits.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	state storeState
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates a MemoryStore holding a copy of `players`, with no pending matches.
+func NewMemoryStore(players map[int]Glicko2Player) *MemoryStore {
+	return &MemoryStore{state: newStoreState(players)}
+}
+
+func (s *MemoryStore) Players(ctx context.Context) (map[int]Glicko2Player, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.state.Players), nil
+}
+
+func (s *MemoryStore) Player(ctx context.Context, id int) (Glicko2Player, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	player, ok := s.state.Players[id]
+	return player, ok, nil
+}
+
+func (s *MemoryStore) SavePlayer(ctx context.Context, id int, player Glicko2Player) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.Players[id] = player
+	return nil
+}
+
+func (s *MemoryStore) PendingMatches(ctx context.Context) ([]Glicko2MatchByID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.state.PendingMatches), nil
+}
+
+func (s *MemoryStore) AddMatches(ctx context.Context, matches []Glicko2MatchByID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.PendingMatches = append(s.state.PendingMatches, matches...)
+	return nil
+}
+
+func (s *MemoryStore) CommitPeriod(ctx context.Context, calculatePeriod func(players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error)) (map[int]Glicko2Player, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	newState, err := s.state.commitPeriod(calculatePeriod)
+	if err != nil {
+		return nil, err
+	}
+	s.state = newState
+	return maps.Clone(newState.Players), nil
+}
+
+//// FileStore
+
+// FileStore is a Store saved as a JSON snapshot file at its path, alongside a JSON Lines journal at the same path with
+// a `.journal` suffix. Its contents are cached in memory.
+//
+// SavePlayer and AddMatches append a single line to the journal, so their cost does not grow with the number of players.
+// CommitPeriod writes a new snapshot by renaming a temporary file, then clears the journal, so a crash at any point
+// leaves either the previous period or the new one. Every change is synced to disk before it returns.
+// Only one FileStore should use a file at a time.
+type FileStore struct {
+	mu          sync.RWMutex
+	path        string
+	journalPath string
+	// generation counts committed periods. Journal entries from earlier generations are already part of the snapshot.
+	generation uint64
+	state      storeState
+}
+
+var _ Store = (*FileStore)(nil)
+
+// fileStoreSnapshot is the contents of a FileStore's snapshot file.
+type fileStoreSnapshot struct {
+	Generation uint64 `json:"generation"`
+	storeState
+}
+
+// fileStoreJournalEntry is a single line of a FileStore's journal, holding either a saved player or added matches.
+type fileStoreJournalEntry struct {
+	Generation uint64             `json:"generation"`
+	PlayerID   *int               `json:"player_id,omitempty"`
+	Player     *Glicko2Player     `json:"player,omitempty"`
+	Matches    []Glicko2MatchByID `json:"matches,omitempty"`
+}
+
+// OpenFileStore opens the FileStore at `path`. If the file does not exist, the store starts empty
+// and the file is created on the first change.
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, journalPath: path + ".journal", state: newStoreState(nil)}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		snapshot := fileStoreSnapshot{storeState: s.state}
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return nil, fmt.Errorf("reading store %v: %w", path, err)
+		}
+		s.generation, s.state = snapshot.Generation, snapshot.storeState
+		if s.state.Players == nil {
+			s.state.Players = make(map[int]Glicko2Player)
+		}
+	}
+
+	if err := s.replayJournal(); err != nil {
+		return nil, fmt.Errorf("reading store journal %v: %w", s.journalPath, err)
+	}
+	return s, nil
+}
+
+// replayJournal applies every journal entry written since the snapshot. A final line without a newline
+// is the remains of an interrupted append, which was never acknowledged, so it is removed.
+func (s *FileStore) replayJournal() error {
+	data, err := os.ReadFile(s.journalPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if complete := bytes.LastIndexByte(data, '\n') + 1; complete < len(data) {
+		if err := os.Truncate(s.journalPath, int64(complete)); err != nil {
+			return err
+		}
+		data = data[:complete]
+	}
+
+	for line := 1; len(data) > 0; line++ {
+		end := bytes.IndexByte(data, '\n')
+
+		var entry fileStoreJournalEntry
+		if err := json.Unmarshal(data[:end], &entry); err != nil {
+			return fmt.Errorf("line %v: %w", line, err)
+		}
+		data = data[end+1:]
+
+		if entry.Generation != s.generation {
+			continue
+		}
+		if entry.PlayerID != nil && entry.Player != nil {
+			s.state.Players[*entry.PlayerID] = *entry.Player
+		}
+		s.state.PendingMatches = append(s.state.PendingMatches, entry.Matches...)
+	}
+	return nil
+}
+
+// appendJournal appends `entry` to the journal and syncs it to disk. If the append fails, the journal is truncated
+// back to its previous length, so a partial line cannot corrupt later entries.
+func (s *FileStore) appendJournal(entry fileStoreJournalEntry) error {
+	entry.Generation = s.generation
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, statErr := os.Stat(s.journalPath)
+	created := errors.Is(statErr, os.ErrNotExist)
+
+	journal, err := os.OpenFile(s.journalPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := journal.Stat()
+	if err != nil {
+		journal.Close()
+		return err
+	}
+
+	if _, err := journal.Write(append(data, '\n')); err != nil {
+		journal.Truncate(info.Size())
+		journal.Close()
+		return err
+	}
+	if err := journal.Sync(); err != nil {
+		journal.Truncate(info.Size())
+		journal.Close()
+		return err
+	}
+	if err := journal.Close(); err != nil {
+		return err
+	}
+	if created {
+		return syncDir(filepath.Dir(s.journalPath))
+	}
+	return nil
+}
+
+// saveSnapshot writes `state` as the snapshot for `generation`, replacing the previous snapshot only once it has been
+// fully written, then syncs the directory so the rename itself survives a crash.
+func (s *FileStore) saveSnapshot(generation uint64, state storeState) error {
+	data, err := json.Marshal(fileStoreSnapshot{Generation: generation, storeState: state})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(s.path))
+}
+
+// syncDir syncs the directory at `path`, so that files created or renamed within it survive a crash.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	if err := dir.Sync(); err != nil {
+		dir.Close()
+		return err
+	}
+	return dir.Close()
+}
+
+func (s *FileStore) Players(ctx context.Context) (map[int]Glicko2Player, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.state.Players), nil
+}
+
+func (s *FileStore) Player(ctx context.Context, id int) (Glicko2Player, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	player, ok := s.state.Players[id]
+	return player, ok, nil
+}
+
+func (s *FileStore) SavePlayer(ctx context.Context, id int, player Glicko2Player) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.appendJournal(fileStoreJournalEntry{PlayerID: &id, Player: &player}); err != nil {
+		return err
+	}
+	s.state.Players[id] = player
+	return nil
+}
+
+func (s *FileStore) PendingMatches(ctx context.Context) ([]Glicko2MatchByID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.state.PendingMatches), nil
+}
+
+func (s *FileStore) AddMatches(ctx context.Context, matches []Glicko2MatchByID) error {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.appendJournal(fileStoreJournalEntry{Matches: matches}); err != nil {
+		return err
+	}
+	s.state.PendingMatches = append(s.state.PendingMatches, matches...)
+	return nil
+}
+
+func (s *FileStore) CommitPeriod(ctx context.Context, calculatePeriod func(players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error)) (map[int]Glicko2Player, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	newState, err := s.state.commitPeriod(calculatePeriod)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveSnapshot(s.generation+1, newState); err != nil {
+		return nil, err
+	}
+	s.generation++
+	s.state = newState
+
+	// The new snapshot's generation excludes every journal entry, so failing to clear the journal
+	// only leaves entries that are skipped when the store is reopened.
+	os.Truncate(s.journalPath, 0)
+	return maps.Clone(newState.Players), nil
+}
@@ -0,0 +1,205 @@
+package glicko2go
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// testStores returns a MemoryStore and a FileStore, both holding the example players.
+func testStores(t *testing.T) map[string]Store {
+	t.Helper()
+	fileStore, err := OpenFileStore(filepath.Join(t.TempDir(), "store.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, player := range getExamplePlayers() {
+		if err := fileStore.SavePlayer(context.Background(), id, player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return map[string]Store{
+		"memory": NewMemoryStore(getExamplePlayers()),
+		"file":   fileStore,
+	}
+}
+
+func TestStoreCommitPeriod(t *testing.T) {
+	ctx := context.Background()
+	calculatePeriod := DefaultPeriodCalculator()
+
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := store.AddMatches(ctx, getExampleMatchList()); err != nil {
+				t.Fatal(err)
+			}
+
+			expectedPlayers, err := calculatePeriod(getExamplePlayers(), getExampleMatchList())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := store.CommitPeriod(ctx, calculatePeriod); err != nil {
+				t.Fatal(err)
+			}
+
+			players, _ := store.Players(ctx)
+			for id, expected := range expectedPlayers {
+				if players[id] != expected {
+					t.Errorf("Player %v was %+v after the period, expected %+v", id, players[id], expected)
+				}
+			}
+			if matches, _ := store.PendingMatches(ctx); len(matches) != 0 {
+				t.Errorf("Committing a period left %v pending matches", len(matches))
+			}
+		})
+	}
+}
+
+func TestStoreFailedCommitLeavesStoreUnchanged(t *testing.T) {
+	ctx := context.Background()
+	errFailed := errors.New("failed period")
+
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			store.AddMatches(ctx, getExampleMatchList())
+
+			_, err := store.CommitPeriod(ctx, func(players map[int]Glicko2Player, matches []Glicko2MatchByID) (map[int]Glicko2Player, error) {
+				// Modifying the inputs must not affect the store
+				players[1] = Glicko2Player{}
+				return nil, errFailed
+			})
+			if !errors.Is(err, errFailed) {
+				t.Fatalf("Expected the calculator's error, got %v", err)
+			}
+
+			if player, _, _ := store.Player(ctx, 1); player != getExamplePlayers()[1] {
+				t.Errorf("A failed period modified player 1: %+v", player)
+			}
+			if matches, _ := store.PendingMatches(ctx); len(matches) != len(getExampleMatchList()) {
+				t.Errorf("A failed period changed the pending matches to %v", matches)
+			}
+		})
+	}
+}
+
+func TestStoreConcurrentMatches(t *testing.T) {
+	ctx := context.Background()
+
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			for range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := store.AddMatches(ctx, getExampleMatchList()); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if matches, _ := store.PendingMatches(ctx); len(matches) != 20*len(getExampleMatchList()) {
+				t.Errorf("Expected %v pending matches, got %v", 20*len(getExampleMatchList()), len(matches))
+			}
+		})
+	}
+}
+
+func TestFileStoreReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, player := range getExamplePlayers() {
+		store.SavePlayer(ctx, id, player)
+	}
+	store.AddMatches(ctx, getExampleMatchList())
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	players, _ := reopened.Players(ctx)
+	for id, expected := range getExamplePlayers() {
+		if players[id] != expected {
+			t.Errorf("Player %v was %+v after reopening, expected %+v", id, players[id], expected)
+		}
+	}
+	if matches, _ := reopened.PendingMatches(ctx); len(matches) != len(getExampleMatchList()) {
+		t.Errorf("Expected %v pending matches after reopening, got %v", len(getExampleMatchList()), len(matches))
+	}
+}
+
+// TestFileStoreJournal checks that only CommitPeriod rewrites the snapshot, and that journal entries from before
+// a commit are not applied again if clearing the journal was interrupted.
+func TestFileStoreJournal(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, player := range getExamplePlayers() {
+		if err := store.SavePlayer(ctx, id, player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddMatches(ctx, getExampleMatchList()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Saving players and adding matches wrote the snapshot: %v", err)
+	}
+
+	journal, err := os.ReadFile(path + ".journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	committedPlayers, err := store.CommitPeriod(ctx, DefaultPeriodCalculator())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path + ".journal"); err != nil || info.Size() != 0 {
+		t.Errorf("Committing a period did not clear the journal: %v", err)
+	}
+
+	// Restore the journal, as if the process crashed after the snapshot was replaced, along with a torn final line
+	if err := os.WriteFile(path+".journal", append(journal, `{"generation":1,"matches":[`...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	players, _ := reopened.Players(ctx)
+	for id, expected := range committedPlayers {
+		if players[id] != expected {
+			t.Errorf("Player %v was %+v after reopening, expected %+v", id, players[id], expected)
+		}
+	}
+	if matches, _ := reopened.PendingMatches(ctx); len(matches) != 0 {
+		t.Errorf("Matches from a committed period were pending again after reopening: %v", matches)
+	}
+
+	if err := reopened.AddMatches(ctx, getExampleMatchList()[:1]); err != nil {
+		t.Fatal(err)
+	}
+	reopenedAgain, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matches, _ := reopenedAgain.PendingMatches(ctx); len(matches) != 1 {
+		t.Errorf("Expected a single pending match after appending past a torn line, got %v", matches)
+	}
+}
@@ -1,5 +1,7 @@
 package glicko2go
 
+import "time"
+
 // GlickoPlayer Represents a player within the original Glicko System.
 // Used to allow conversions from Glicko 2 to the old scale, if preferred.
 type GlickoPlayer struct {
@@ -8,14 +10,22 @@ type GlickoPlayer struct {
 }
 
 // Glicko2Player Represents a player within the Glicko 2 rating system.
+// When encoded as JSON, the fields of the embedded GlickoPlayer are flattened. See Glicko2Player.MarshalJSON.
 type Glicko2Player struct {
 	GlickoPlayer
 	RatingVolatility float64
 }
 
 type Glicko2AlgorithmSettings struct {
-	SystemConstant       float64
-	ConvergenceTolerance float64
+	SystemConstant       float64 `json:"system_constant"`
+	ConvergenceTolerance float64 `json:"convergence_tolerance"`
+	// MaxIterations bounds each loop of the volatility solver. If 0, GLICKO2_DEFAULT_MAX_ITERATIONS is used.
+	MaxIterations int `json:"max_iterations,omitempty"`
+	// MaxDeviation caps the pre-rating deviation (`φ*`) on the Glicko 2 scale, so long-inactive players do not become
+	// absurdly uncertain. If 0, GLICKO2_DEFAULT_MAX_DEVIATION is used.
+	MaxDeviation float64 `json:"max_deviation"`
+	// DisableMaxDeviation removes the cap on pre-rating deviation, ignoring MaxDeviation.
+	DisableMaxDeviation bool `json:"disable_max_deviation,omitempty"`
 }
 
 type Glicko2PlayerPeriodMatches struct {
@@ -23,13 +33,33 @@ type Glicko2PlayerPeriodMatches struct {
 	Results   []float64
 }
 
-type Glicko2MatchByID struct {
-	Player1ID int
-	Player2ID int
-	Result    float64
+// Glicko2Match Represents a match between two players identified by any comparable ID type,
+// such as database keys or UUID strings. `Result` is the outcome from the perspective of `Player1ID`.
+type Glicko2Match[ID comparable] struct {
+	Player1ID ID      `json:"player1_id"`
+	Player2ID ID      `json:"player2_id"`
+	Result    float64 `json:"result"`
+	// PlayedAt is used to place the match within a PeriodSchedule. It is ignored by period calculators.
+	PlayedAt time.Time `json:"played_at,omitzero"`
 }
 
+// Glicko2MatchByID Represents a match between two players identified by `int` IDs.
+type Glicko2MatchByID = Glicko2Match[int]
+
 type Glicko2MatchForPlayer struct {
-	Opponent Glicko2Player
-	Result   float64
+	Opponent Glicko2Player `json:"opponent"`
+	Result   float64       `json:"result"`
+}
+
+// GlickoAlgorithmSettings holds the constants used for the original Glicko system.
+type GlickoAlgorithmSettings struct {
+	// DeviationGrowthConstant is `c` from step 1, which determines how quickly rating deviation grows over each period.
+	DeviationGrowthConstant float64 `json:"deviation_growth_constant"`
+	// MaxDeviation caps rating deviation in step 1. If 0, GLICKO_DEFAULT_PLAYER_DEVIATION is used.
+	MaxDeviation float64 `json:"max_deviation"`
+}
+
+type GlickoMatchForPlayer struct {
+	Opponent GlickoPlayer `json:"opponent"`
+	Result   float64      `json:"result"`
 }
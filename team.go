@@ -0,0 +1,266 @@
+package glicko2go
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Glicko2TeamMatch Represents a match between two teams of players identified by any comparable ID type.
+// `Result` is the outcome from the perspective of `Team1`, and applies to every member of the team.
+type Glicko2TeamMatch[ID comparable] struct {
+	Team1  []ID    `json:"team1"`
+	Team2  []ID    `json:"team2"`
+	Result float64 `json:"result"`
+	// PlayedAt records when the match was played. It is ignored by team period calculators.
+	PlayedAt time.Time `json:"played_at,omitzero"`
+}
+
+// Glicko2TeamMatchByID Represents a team match between players identified by `int` IDs.
+type Glicko2TeamMatchByID = Glicko2TeamMatch[int]
+
+// TeamAggregationStrategy determines how a team match is turned into games for each member to be rated on.
+// With every strategy, each member keeps their own rating, deviation and volatility, and is updated via UpdatePlayerFromMatches.
+type TeamAggregationStrategy int
+
+// Each team is summarised by a composite player, whose rating is the mean rating of its members
+// and whose deviation is the root mean square of their deviations.
+const (
+	// TEAM_AGGREGATION_COMPOSITE_OPPONENT rates each team as its composite player against the opposing team's composite.
+	// Each member plays a single game whose rating difference is that between the two composites, so every member of a team
+	// shares an expected score regardless of their own rating, and members with equal deviations move equally.
+	TEAM_AGGREGATION_COMPOSITE_OPPONENT TeamAggregationStrategy = iota
+	// TEAM_AGGREGATION_INDIVIDUAL_VS_TEAM_AVERAGE has each member play a single game, at their own rating,
+	// against the opposing team's composite. Stronger members therefore gain less from a win than weaker teammates.
+	TEAM_AGGREGATION_INDIVIDUAL_VS_TEAM_AVERAGE
+	// TEAM_AGGREGATION_PER_PAIR_DECOMPOSITION decomposes the team match into a separate game between each member
+	// and every member of the opposing team, all with the team's result. Each team match counts as several games,
+	// so ratings move further than with other strategies.
+	TEAM_AGGREGATION_PER_PAIR_DECOMPOSITION
+)
+
+func (s TeamAggregationStrategy) String() string {
+	switch s {
+	case TEAM_AGGREGATION_COMPOSITE_OPPONENT:
+		return "composite opponent"
+	case TEAM_AGGREGATION_INDIVIDUAL_VS_TEAM_AVERAGE:
+		return "individual vs team average"
+	case TEAM_AGGREGATION_PER_PAIR_DECOMPOSITION:
+		return "per-pair decomposition"
+	default:
+		return fmt.Sprintf("TeamAggregationStrategy(%d)", int(s))
+	}
+}
+
+// TeamMatchIssue is a single problem found with a team match. A match may have several issues.
+type TeamMatchIssue[ID comparable] struct {
+	// MatchIndex is the index of the offending match within the slice passed to the period calculator.
+	MatchIndex int
+	Match      Glicko2TeamMatch[ID]
+	Kind       MatchIssueKind
+	// PlayerID is the offending ID when Kind is MATCH_ISSUE_UNKNOWN_PLAYER or MATCH_ISSUE_SELF_PLAY.
+	PlayerID ID
+}
+
+func (i TeamMatchIssue[ID]) String() string {
+	switch i.Kind {
+	case MATCH_ISSUE_UNKNOWN_PLAYER:
+		return fmt.Sprintf("team match %v: unknown player ID %v", i.MatchIndex, i.PlayerID)
+	case MATCH_ISSUE_SELF_PLAY:
+		return fmt.Sprintf("team match %v: player %v appears more than once", i.MatchIndex, i.PlayerID)
+	case MATCH_ISSUE_RESULT_OUT_OF_RANGE:
+		return fmt.Sprintf("team match %v: result %v is outside of [%v, %v]", i.MatchIndex, i.Match.Result, GAME_OUTCOME_LOSS, GAME_OUTCOME_WIN)
+	default:
+		return fmt.Sprintf("team match %v: %v", i.MatchIndex, i.Kind)
+	}
+}
+
+// TeamMatchValidationError is returned by team period calculators when one or more team matches are invalid.
+// Every issue found is listed, rather than only the first.
+type TeamMatchValidationError[ID comparable] struct {
+	Issues []TeamMatchIssue[ID]
+}
+
+func (e *TeamMatchValidationError[ID]) Error() string {
+	descriptions := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		descriptions[i] = issue.String()
+	}
+	return fmt.Sprintf("%v invalid team match issue(s): %v", len(e.Issues), strings.Join(descriptions, "; "))
+}
+
+// ValidateTeamMatches checks every team match against `players`, returning all issues found.
+// Each side must have at least one player, and no player may appear more than once within a match.
+// A nil slice is returned if every match is valid.
+func ValidateTeamMatches[ID comparable, P any](players map[ID]P, matches []Glicko2TeamMatch[ID]) []TeamMatchIssue[ID] {
+	var issues []TeamMatchIssue[ID]
+
+	for idx, match := range matches {
+		if len(match.Team1) == 0 || len(match.Team2) == 0 {
+			issues = append(issues, TeamMatchIssue[ID]{MatchIndex: idx, Match: match, Kind: MATCH_ISSUE_EMPTY_TEAM})
+		}
+
+		seen := make(map[ID]bool, len(match.Team1)+len(match.Team2))
+		for _, team := range [][]ID{match.Team1, match.Team2} {
+			for _, playerID := range team {
+				if seen[playerID] {
+					issues = append(issues, TeamMatchIssue[ID]{MatchIndex: idx, Match: match, Kind: MATCH_ISSUE_SELF_PLAY, PlayerID: playerID})
+					continue
+				}
+				seen[playerID] = true
+
+				if _, ok := players[playerID]; !ok {
+					issues = append(issues, TeamMatchIssue[ID]{MatchIndex: idx, Match: match, Kind: MATCH_ISSUE_UNKNOWN_PLAYER, PlayerID: playerID})
+				}
+			}
+		}
+
+		if math.IsNaN(match.Result) || match.Result < GAME_OUTCOME_LOSS || match.Result > GAME_OUTCOME_WIN {
+			issues = append(issues, TeamMatchIssue[ID]{MatchIndex: idx, Match: match, Kind: MATCH_ISSUE_RESULT_OUT_OF_RANGE})
+		}
+	}
+
+	return issues
+}
+
+// compositeTeamPlayer returns a single player representing `team`, with the mean rating and volatility of its members,
+// and the root mean square of their deviations.
+func compositeTeamPlayer[ID comparable](players map[ID]Glicko2Player, team []ID) Glicko2Player {
+	var ratingSum, deviationSquareSum, volatilitySum float64
+	for _, playerID := range team {
+		ratingSum += players[playerID].Rating
+		deviationSquareSum += math.Pow(players[playerID].RatingDeviation, 2)
+		volatilitySum += players[playerID].RatingVolatility
+	}
+
+	teamSize := float64(len(team))
+	return Glicko2Player{
+		GlickoPlayer: GlickoPlayer{
+			Rating:          ratingSum / teamSize,
+			RatingDeviation: math.Sqrt(deviationSquareSum / teamSize),
+		},
+		RatingVolatility: volatilitySum / teamSize,
+	}
+}
+
+// teamPeriodGames holds the games a single player is rated on, in the form taken by UpdatePlayerFromMatches.
+type teamPeriodGames struct {
+	opponentRatings    []float64
+	opponentDeviations []float64
+	gameOutcomes       []float64
+}
+
+func (g *teamPeriodGames) add(opponentRating float64, opponentDeviation float64, outcome float64) {
+	g.opponentRatings = append(g.opponentRatings, opponentRating)
+	g.opponentDeviations = append(g.opponentDeviations, opponentDeviation)
+	g.gameOutcomes = append(g.gameOutcomes, outcome)
+}
+
+// UpdateTeamPeriodByID calculates every player's stats after a period of team matches, using `strategy` to turn each team
+// match into games for its members. Every player is updated once, including those who have not played, and only pre-period
+// stats are used to build each game.
+//
+// With MATCH_VALIDATION_STRICT, any invalid match causes a *TeamMatchValidationError to be returned and no players are updated.
+// With MATCH_VALIDATION_LENIENT, invalid matches are skipped and returned as a report alongside the updated players.
+func UpdateTeamPeriodByID[ID comparable](r *Rater, players map[ID]Glicko2Player, matches []Glicko2TeamMatch[ID], strategy TeamAggregationStrategy,
+	policy MatchValidationPolicy) (map[ID]Glicko2Player, []TeamMatchIssue[ID], error) {
+
+	if strategy < TEAM_AGGREGATION_COMPOSITE_OPPONENT || strategy > TEAM_AGGREGATION_PER_PAIR_DECOMPOSITION {
+		return nil, nil, fmt.Errorf("unknown team aggregation strategy %v", strategy)
+	}
+
+	issues := ValidateTeamMatches(players, matches)
+	invalid := make(map[int]bool, len(issues))
+	if len(issues) > 0 {
+		if policy != MATCH_VALIDATION_LENIENT {
+			return nil, nil, &TeamMatchValidationError[ID]{Issues: issues}
+		}
+		for _, issue := range issues {
+			invalid[issue.MatchIndex] = true
+		}
+	}
+
+	periodGames := make(map[ID]*teamPeriodGames)
+	gamesFor := func(playerID ID) *teamPeriodGames {
+		if periodGames[playerID] == nil {
+			periodGames[playerID] = &teamPeriodGames{}
+		}
+		return periodGames[playerID]
+	}
+
+	for idx, match := range matches {
+		if invalid[idx] {
+			continue
+		}
+
+		sides := []struct {
+			team      []ID
+			opponents []ID
+			result    float64
+		}{
+			{match.Team1, match.Team2, match.Result},
+			{match.Team2, match.Team1, 1 - match.Result},
+		}
+
+		for _, side := range sides {
+			opponentComposite := compositeTeamPlayer(players, side.opponents)
+			ownComposite := compositeTeamPlayer(players, side.team)
+
+			for _, playerID := range side.team {
+				switch strategy {
+				case TEAM_AGGREGATION_COMPOSITE_OPPONENT:
+					// Offsetting the opponent keeps the member's rating difference equal to the difference between the composites
+					offsetRating := players[playerID].Rating + opponentComposite.Rating - ownComposite.Rating
+					gamesFor(playerID).add(offsetRating, opponentComposite.RatingDeviation, side.result)
+				case TEAM_AGGREGATION_INDIVIDUAL_VS_TEAM_AVERAGE:
+					gamesFor(playerID).add(opponentComposite.Rating, opponentComposite.RatingDeviation, side.result)
+				case TEAM_AGGREGATION_PER_PAIR_DECOMPOSITION:
+					for _, opponentID := range side.opponents {
+						gamesFor(playerID).add(players[opponentID].Rating, players[opponentID].RatingDeviation, side.result)
+					}
+				}
+			}
+		}
+	}
+
+	updatedPlayers := make(map[ID]Glicko2Player, len(players))
+
+	for playerID, player := range players {
+		games := periodGames[playerID]
+		if games == nil {
+			games = &teamPeriodGames{}
+		}
+
+		updatedPlayer, err := r.UpdatePlayerRaw(player.Rating, player.RatingDeviation, player.RatingVolatility,
+			games.opponentRatings, games.opponentDeviations, games.gameOutcomes)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		updatedPlayers[playerID] = updatedPlayer
+	}
+
+	return updatedPlayers, issues, nil
+}
+
+// GenericTeamPeriodCalculatorWithSettings returns a function that calculates every player's stats after a period of team matches,
+// using `strategy`. Any invalid match fails the whole period. See UpdateTeamPeriodByID.
+func GenericTeamPeriodCalculatorWithSettings[ID comparable](settings Glicko2AlgorithmSettings, strategy TeamAggregationStrategy) func(
+	players map[ID]Glicko2Player,
+	matches []Glicko2TeamMatch[ID]) (map[ID]Glicko2Player, error) {
+
+	rater := NewRater(settings)
+
+	return func(players map[ID]Glicko2Player, matches []Glicko2TeamMatch[ID]) (map[ID]Glicko2Player, error) {
+		updatedPlayers, _, err := UpdateTeamPeriodByID(rater, players, matches, strategy, MATCH_VALIDATION_STRICT)
+		return updatedPlayers, err
+	}
+}
+
+// TeamPeriodCalculatorWithSettings is GenericTeamPeriodCalculatorWithSettings for `int` player IDs.
+func TeamPeriodCalculatorWithSettings(settings Glicko2AlgorithmSettings, strategy TeamAggregationStrategy) func(
+	players map[int]Glicko2Player,
+	matches []Glicko2TeamMatchByID) (map[int]Glicko2Player, error) {
+	return GenericTeamPeriodCalculatorWithSettings[int](settings, strategy)
+}
@@ -0,0 +1,240 @@
+package glicko2go
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+var teamAggregationStrategies = []TeamAggregationStrategy{
+	TEAM_AGGREGATION_COMPOSITE_OPPONENT,
+	TEAM_AGGREGATION_INDIVIDUAL_VS_TEAM_AVERAGE,
+	TEAM_AGGREGATION_PER_PAIR_DECOMPOSITION,
+}
+
+// getMirroredTeamPlayers returns two teams of 5, where each member of team 1 has an identical counterpart in team 2.
+// Every player shares a deviation and volatility.
+func getMirroredTeamPlayers() (map[int]Glicko2Player, Glicko2TeamMatchByID) {
+	players := make(map[int]Glicko2Player)
+	match := Glicko2TeamMatchByID{}
+	for idx, rating := range []float64{1300, 1450, 1500, 1620, 1800} {
+		player := ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{Rating: rating, RatingDeviation: 80})
+		players[idx] = player
+		players[idx+10] = player
+		match.Team1 = append(match.Team1, idx)
+		match.Team2 = append(match.Team2, idx+10)
+	}
+	return players, match
+}
+
+func ratingChangeSum(before map[int]Glicko2Player, after map[int]Glicko2Player) float64 {
+	sum := 0.0
+	for id, player := range after {
+		sum += player.Rating - before[id].Rating
+	}
+	return sum
+}
+
+// getMixedStrengthTeamPlayers returns two teams of 5 with different ratings and team means, where team 1 is stronger.
+// Every player shares a deviation and volatility.
+func getMixedStrengthTeamPlayers() (map[int]Glicko2Player, Glicko2TeamMatchByID) {
+	players := make(map[int]Glicko2Player)
+	match := Glicko2TeamMatchByID{}
+	for idx, rating := range []float64{1300, 1450, 1500, 1620, 1800} {
+		players[idx] = ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{Rating: rating, RatingDeviation: 80})
+		match.Team1 = append(match.Team1, idx)
+	}
+	for idx, rating := range []float64{1250, 1380, 1400, 1475, 1560} {
+		players[idx+10] = ConvertToGlicko2WithDefaultVolatility(GlickoPlayer{Rating: rating, RatingDeviation: 80})
+		match.Team2 = append(match.Team2, idx+10)
+	}
+	return players, match
+}
+
+// TestCompositeOpponentConservesRatingBetweenMixedTeams checks that, with TEAM_AGGREGATION_COMPOSITE_OPPONENT, teams of different
+// strengths exchange exactly the same amount of rating. With equal deviations, each team's expected score is the complement
+// of the other's, so every member of one team moves by exactly the opposite of every member of the other.
+func TestCompositeOpponentConservesRatingBetweenMixedTeams(t *testing.T) {
+	players, match := getMixedStrengthTeamPlayers()
+
+	changes := make(map[float64]float64)
+	for _, result := range []float64{GAME_OUTCOME_WIN, GAME_OUTCOME_DRAW, GAME_OUTCOME_LOSS} {
+		match.Result = result
+		updatedPlayers, err := TeamPeriodCalculatorWithSettings(glicko2DefaultSettings, TEAM_AGGREGATION_COMPOSITE_OPPONENT)(players, []Glicko2TeamMatchByID{match})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sum := ratingChangeSum(players, updatedPlayers); math.Abs(sum) > 1e-12 {
+			t.Errorf("Result %v changed the total rating by %v", result, sum)
+		}
+		changes[result] = updatedPlayers[0].Rating - players[0].Rating
+		for idx := range match.Team1 {
+			team1Change := updatedPlayers[match.Team1[idx]].Rating - players[match.Team1[idx]].Rating
+			team2Change := updatedPlayers[match.Team2[idx]].Rating - players[match.Team2[idx]].Rating
+			if math.Abs(team1Change+team2Change) > 1e-12 {
+				t.Errorf("Result %v moved players %v and %v by %v and %v", result, match.Team1[idx], match.Team2[idx], team1Change, team2Change)
+			}
+		}
+	}
+
+	// The stronger team is expected to win, so a win gains less than a loss costs, and a draw costs rating
+	if changes[GAME_OUTCOME_WIN] <= 0 || changes[GAME_OUTCOME_DRAW] >= 0 || changes[GAME_OUTCOME_WIN] >= -changes[GAME_OUTCOME_LOSS] {
+		t.Errorf("Unexpected changes for the stronger team: %v", changes)
+	}
+}
+
+// TestCompositeOpponentDoesNotConserveRatingBetweenUnequalTeams checks that, with TEAM_AGGREGATION_COMPOSITE_OPPONENT,
+// conservation depends on team sizes. Every member still moves by the opposite of every opposing member,
+// so a team of 5 beating a team of 3 gains 5/3 of what the losers give up.
+func TestCompositeOpponentDoesNotConserveRatingBetweenUnequalTeams(t *testing.T) {
+	players, match := getMixedStrengthTeamPlayers()
+	match.Team2 = match.Team2[:3]
+	match.Result = GAME_OUTCOME_WIN
+
+	updatedPlayers, err := TeamPeriodCalculatorWithSettings(glicko2DefaultSettings, TEAM_AGGREGATION_COMPOSITE_OPPONENT)(players, []Glicko2TeamMatchByID{match})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	team1Change := updatedPlayers[match.Team1[0]].Rating - players[match.Team1[0]].Rating
+	team2Change := updatedPlayers[match.Team2[0]].Rating - players[match.Team2[0]].Rating
+	if team1Change <= 0 || math.Abs(team1Change+team2Change) > 1e-12 {
+		t.Errorf("Members of the winning and losing teams moved by %v and %v", team1Change, team2Change)
+	}
+
+	expectedSum := float64(len(match.Team1)-len(match.Team2)) * team1Change
+	if sum := ratingChangeSum(players, updatedPlayers); math.Abs(sum-expectedSum) > 1e-12 || math.Abs(sum) < 1e-3 {
+		t.Errorf("Expected unequal teams to change the total rating by %v, got %v", expectedSum, sum)
+	}
+}
+
+// TestTeamMatchApproximatelyConservesRating checks that strategies where teammates are rated on different games still
+// conserve most of the rating exchanged. Glicko 2 is not zero-sum, as each player's step size depends on their own variance.
+func TestTeamMatchApproximatelyConservesRating(t *testing.T) {
+	players, match := getMirroredTeamPlayers()
+	match.Result = GAME_OUTCOME_WIN
+
+	for _, strategy := range teamAggregationStrategies {
+		updatedPlayers, err := TeamPeriodCalculatorWithSettings(glicko2DefaultSettings, strategy)(players, []Glicko2TeamMatchByID{match})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		team1Gain, team2Gain := 0.0, 0.0
+		for idx := range match.Team1 {
+			team1Gain += updatedPlayers[match.Team1[idx]].Rating - players[match.Team1[idx]].Rating
+			team2Gain += updatedPlayers[match.Team2[idx]].Rating - players[match.Team2[idx]].Rating
+		}
+		if team1Gain <= 0 || team2Gain >= 0 {
+			t.Errorf("%v gave the winners %v and the losers %v", strategy, team1Gain, team2Gain)
+		}
+		if imbalance := math.Abs(team1Gain+team2Gain) / team1Gain; imbalance > 0.02 {
+			t.Errorf("%v created or destroyed %.2f%% of the rating exchanged", strategy, imbalance*100)
+		}
+	}
+}
+
+// TestTeamMatchDrawBetweenEqualTeams checks that a composite opponent draw between teams with equal means leaves every rating unchanged.
+func TestTeamMatchDrawBetweenEqualTeams(t *testing.T) {
+	players, match := getMirroredTeamPlayers()
+	match.Result = GAME_OUTCOME_DRAW
+
+	updatedPlayers, err := TeamPeriodCalculatorWithSettings(glicko2DefaultSettings, TEAM_AGGREGATION_COMPOSITE_OPPONENT)(players, []Glicko2TeamMatchByID{match})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, player := range updatedPlayers {
+		if math.Abs(player.Rating-players[id].Rating) > 1e-12 {
+			t.Errorf("Player %v's rating changed from %v to %v after drawing between equal teams", id, players[id].Rating, player.Rating)
+		}
+	}
+}
+
+// TestCompositeOpponentSharesRatingChange checks that teammates with equal deviations move equally, regardless of their ratings.
+func TestCompositeOpponentSharesRatingChange(t *testing.T) {
+	players, match := getMirroredTeamPlayers()
+	match.Result = GAME_OUTCOME_WIN
+
+	updatedPlayers, err := TeamPeriodCalculatorWithSettings(glicko2DefaultSettings, TEAM_AGGREGATION_COMPOSITE_OPPONENT)(players, []Glicko2TeamMatchByID{match})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChange := updatedPlayers[0].Rating - players[0].Rating
+	for _, id := range match.Team1 {
+		if change := updatedPlayers[id].Rating - players[id].Rating; math.Abs(change-expectedChange) > 1e-12 {
+			t.Errorf("Player %v gained %v, while player 0 gained %v", id, change, expectedChange)
+		}
+	}
+}
+
+// TestOneVersusOneTeamMatchesMatchPeriodCalculator checks that teams of 1 are rated exactly as individual matches, with every strategy.
+func TestOneVersusOneTeamMatchesMatchPeriodCalculator(t *testing.T) {
+	players, matchList := getExamplePlayersAndMatches()
+	referencePlayers, err := DefaultPeriodCalculator()(players, matchList)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	teamMatches := make([]Glicko2TeamMatchByID, 0, len(matchList))
+	for _, match := range matchList {
+		teamMatches = append(teamMatches, Glicko2TeamMatchByID{Team1: []int{match.Player1ID}, Team2: []int{match.Player2ID}, Result: match.Result})
+	}
+
+	for _, strategy := range teamAggregationStrategies {
+		updatedPlayers, err := TeamPeriodCalculatorWithSettings(glicko2DefaultSettings, strategy)(players, teamMatches)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for id, player := range referencePlayers {
+			if updatedPlayers[id] != player {
+				t.Errorf("%v differs for player %v\nTeam:       %v\nIndividual: %v", strategy, id, updatedPlayers[id], player)
+			}
+		}
+	}
+}
+
+func TestTeamMatchValidation(t *testing.T) {
+	players, match := getMirroredTeamPlayers()
+	matches := []Glicko2TeamMatchByID{
+		match,
+		{Team1: []int{0, 1}, Team2: []int{1, 99}, Result: GAME_OUTCOME_WIN},
+		{Team1: nil, Team2: []int{10}, Result: 2},
+	}
+
+	_, err := TeamPeriodCalculatorWithSettings(glicko2DefaultSettings, TEAM_AGGREGATION_COMPOSITE_OPPONENT)(players, matches)
+	var validationErr *TeamMatchValidationError[int]
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a *TeamMatchValidationError, got %v", err)
+	}
+
+	expectedKinds := []MatchIssueKind{MATCH_ISSUE_SELF_PLAY, MATCH_ISSUE_UNKNOWN_PLAYER, MATCH_ISSUE_EMPTY_TEAM, MATCH_ISSUE_RESULT_OUT_OF_RANGE}
+	if len(validationErr.Issues) != len(expectedKinds) {
+		t.Fatalf("Expected %v issues, got %v", len(expectedKinds), validationErr.Issues)
+	}
+	for idx, issue := range validationErr.Issues {
+		if issue.Kind != expectedKinds[idx] {
+			t.Errorf("Expected issue %v to be %v, got %v", idx, expectedKinds[idx], issue)
+		}
+	}
+
+	// A lenient period only rates the valid match
+	lenientPlayers, issues, err := UpdateTeamPeriodByID(NewDefaultRater(), players, matches, TEAM_AGGREGATION_COMPOSITE_OPPONENT, MATCH_VALIDATION_LENIENT)
+	if err != nil || len(issues) != len(expectedKinds) {
+		t.Fatalf("Expected a lenient period to report %v issues, got %v (%v)", len(expectedKinds), issues, err)
+	}
+	strictPlayers, err := TeamPeriodCalculatorWithSettings(glicko2DefaultSettings, TEAM_AGGREGATION_COMPOSITE_OPPONENT)(players, matches[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, player := range strictPlayers {
+		if lenientPlayers[id] != player {
+			t.Errorf("Player %v differs after skipping invalid matches", id)
+		}
+	}
+
+	if _, _, err := UpdateTeamPeriodByID(NewDefaultRater(), players, matches[:1], TeamAggregationStrategy(-1), MATCH_VALIDATION_STRICT); err == nil {
+		t.Errorf("An unknown strategy did not cause an error")
+	}
+}
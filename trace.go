@@ -0,0 +1,123 @@
+package glicko2go
+
+// Glicko2GameTrace holds the intermediate values calculated for a single game within a period (step 3).
+type Glicko2GameTrace struct {
+	OpponentRating    float64 `json:"opponent_rating"`
+	OpponentDeviation float64 `json:"opponent_deviation"`
+	Outcome           float64 `json:"outcome"`
+	// G is `g(φj)`.
+	G float64 `json:"g"`
+	// E is `E(µ, µj, φj)`.
+	E float64 `json:"e"`
+}
+
+// Glicko2VolatilityIteration holds the values of a single iteration of the volatility solver (step 5.4).
+// A, B and FA, FB are the values after the iteration has completed.
+type Glicko2VolatilityIteration struct {
+	A  float64 `json:"a"`
+	B  float64 `json:"b"`
+	C  float64 `json:"c"`
+	FA float64 `json:"f_a"`
+	FB float64 `json:"f_b"`
+	FC float64 `json:"f_c"`
+}
+
+// Glicko2VolatilityTrace holds the intermediate values of the volatility solver (step 5).
+type Glicko2VolatilityTrace struct {
+	// LowerA is `a = ln(σ²)` from step 5.1.
+	LowerA float64 `json:"a"`
+	// K is the `k` used to bracket B in step 5.2, or 0 if `∆² > φ² + v`.
+	K          float64                      `json:"k"`
+	InitialA   float64                      `json:"initial_a"`
+	InitialB   float64                      `json:"initial_b"`
+	InitialFA  float64                      `json:"initial_f_a"`
+	InitialFB  float64                      `json:"initial_f_b"`
+	Iterations []Glicko2VolatilityIteration `json:"iterations"`
+}
+
+// Glicko2CalculationTrace holds every intermediate value calculated while updating a player, as named within the paper.
+// All values are on the Glicko 2 scale. It can be serialised (e.g. via encoding/json) to explain a rating change.
+//
+// For more details, see https://www.glicko.net/glicko/glicko2.pdf
+type Glicko2CalculationTrace struct {
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`
+	Volatility float64 `json:"volatility"`
+	// ElapsedPeriods is `t`, the number of periods the deviation was grown by in step 6.
+	ElapsedPeriods float64                  `json:"elapsed_periods"`
+	Settings       Glicko2AlgorithmSettings `json:"settings"`
+	Games          []Glicko2GameTrace       `json:"games"`
+
+	// Variance is `v` from step 3. It is 0 if no games were played.
+	Variance float64 `json:"variance"`
+	// Delta is `∆` from step 4. It is 0 if no games were played.
+	Delta            float64                `json:"delta"`
+	VolatilitySolver Glicko2VolatilityTrace `json:"volatility_solver"`
+
+	// PreRatingDeviation is `φ*` from step 6.
+	PreRatingDeviation float64 `json:"pre_rating_deviation"`
+	// NewRating is `µ′` from step 7.
+	NewRating float64 `json:"new_rating"`
+	// NewDeviation is `φ′` from step 7.
+	NewDeviation float64 `json:"new_deviation"`
+	// NewVolatility is `σ′` from step 5.
+	NewVolatility float64 `json:"new_volatility"`
+}
+
+// recordInputs records the player, their games and `g(φj)` and `E(µ, µj, φj)` for each game.
+func (t *Glicko2CalculationTrace) recordInputs(playerRating float64, playerDeviation float64, playerVolatility float64,
+	opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64, elapsedPeriods float64, settings Glicko2AlgorithmSettings) {
+
+	t.Rating = playerRating
+	t.Deviation = playerDeviation
+	t.Volatility = playerVolatility
+	t.ElapsedPeriods = elapsedPeriods
+	t.Settings = settings
+
+	t.Games = make([]Glicko2GameTrace, len(gameOutcomes))
+	for i := range gameOutcomes {
+		t.Games[i] = Glicko2GameTrace{
+			OpponentRating:    opponentRatings[i],
+			OpponentDeviation: opponentDeviations[i],
+			Outcome:           gameOutcomes[i],
+			G:                 step3g(opponentDeviations[i]),
+			E:                 step3E(playerRating, opponentRatings[i], opponentDeviations[i]),
+		}
+	}
+}
+
+func (t *Glicko2CalculationTrace) recordOutputs(preRatingDeviation float64, newRating float64, newDeviation float64, newVolatility float64) {
+	t.PreRatingDeviation = preRatingDeviation
+	t.NewRating = newRating
+	t.NewDeviation = newDeviation
+	t.NewVolatility = newVolatility
+}
+
+// UpdatePlayerFromMatchesWithTrace is equivalent to UpdatePlayerFromMatches, but returns every intermediate value
+// calculated alongside the result. The trace's NewRating, NewDeviation and NewVolatility are the updated player's stats.
+func UpdatePlayerFromMatchesWithTrace(playerRating float64, playerDeviation float64, playerVolatility float64, opponentRatings []float64, opponentDeviations []float64, gameOutcomes []float64,
+	settings Glicko2AlgorithmSettings) (Glicko2CalculationTrace, error) {
+
+	var trace Glicko2CalculationTrace
+	_, _, _, err := updatePlayerFromMatches(playerRating, playerDeviation, playerVolatility, opponentRatings, opponentDeviations, gameOutcomes, 1, settings, &trace)
+	if err != nil {
+		return Glicko2CalculationTrace{}, err
+	}
+	return trace, nil
+}
+
+// TraceUpdatePlayer is equivalent to Rater.UpdatePlayer, but returns every intermediate value calculated.
+// See UpdatePlayerFromMatchesWithTrace.
+func (r *Rater) TraceUpdatePlayer(player Glicko2Player, periodGames []Glicko2MatchForPlayer) (Glicko2CalculationTrace, error) {
+	opponentRatings := make([]float64, 0, len(periodGames))
+	opponentDeviations := make([]float64, 0, len(periodGames))
+	gameResults := make([]float64, 0, len(periodGames))
+
+	for _, game := range periodGames {
+		opponentRatings = append(opponentRatings, game.Opponent.Rating)
+		opponentDeviations = append(opponentDeviations, game.Opponent.RatingDeviation)
+		gameResults = append(gameResults, game.Result)
+	}
+
+	return UpdatePlayerFromMatchesWithTrace(player.Rating, player.RatingDeviation, player.RatingVolatility, opponentRatings, opponentDeviations, gameResults, r.settings)
+}
@@ -0,0 +1,124 @@
+package glicko2go
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// GLICKO2_TUNING_PROBABILITY_EPSILON bounds predicted probabilities away from 0 and 1,
+// so a single confident but wrong prediction cannot make log-loss infinite.
+//
+// Deprecated: Use GLICKO2_PROBABILITY_EPSILON, which is shared with PredictionLogLoss.
+const GLICKO2_TUNING_PROBABILITY_EPSILON = GLICKO2_PROBABILITY_EPSILON
+
+// SystemConstantTuningOptions holds the search performed by TuneSystemConstant.
+type SystemConstantTuningOptions struct {
+	// Settings are used for every setting other than SystemConstant.
+	Settings Glicko2AlgorithmSettings
+	// Candidates are the values of SystemConstant to score. If empty, 10 evenly spaced values
+	// from GLICKO2_LOW_SYSTEM_CONSTANT to GLICKO2_HIGH_SYSTEM_CONSTANT are used. See SystemConstantRange.
+	Candidates []float64
+	// HeldOutPeriods is the number of final periods whose matches are scored. Must be at least 1.
+	HeldOutPeriods int
+}
+
+// SystemConstantScore is the predictive log-loss of a single SystemConstant over the held-out matches.
+type SystemConstantScore struct {
+	SystemConstant float64 `json:"system_constant"`
+	LogLoss        float64 `json:"log_loss"`
+}
+
+// SystemConstantTuning is the result of TuneSystemConstant.
+type SystemConstantTuning struct {
+	// Best holds the settings with the lowest log-loss.
+	Best Glicko2AlgorithmSettings `json:"best"`
+	// Scores holds every candidate's log-loss, ordered by SystemConstant.
+	Scores []SystemConstantScore `json:"scores"`
+	// HeldOutMatches is the number of matches each candidate was scored on.
+	HeldOutMatches int `json:"held_out_matches"`
+}
+
+// SystemConstantRange returns `count` evenly spaced values from `min` to `max` inclusive.
+func SystemConstantRange(min float64, max float64, count int) []float64 {
+	if count < 2 {
+		return []float64{min}
+	}
+	values := make([]float64, count)
+	for i := range values {
+		values[i] = min + (max-min)*float64(i)/float64(count-1)
+	}
+	return values
+}
+
+// TuneSystemConstant replays `periods` in order with every candidate SystemConstant, via ReplayPeriodSeqFunc,
+// and scores each candidate by the mean log-loss of its predictions for the matches of the final `HeldOutPeriods` periods.
+//
+// Every match is predicted with Glicko2Player.WinProbability, using the players from before the match's period,
+// so no candidate sees a match before predicting it. Held-out periods still update players once they have been scored.
+// Draws are scored as half a win.
+func TuneSystemConstant[ID comparable](players map[ID]Glicko2Player, periods [][]Glicko2Match[ID], options SystemConstantTuningOptions) (SystemConstantTuning, error) {
+	if options.HeldOutPeriods < 1 || options.HeldOutPeriods > len(periods) {
+		return SystemConstantTuning{}, fmt.Errorf("held out periods must be between 1 and the number of periods (%v), got %v", len(periods), options.HeldOutPeriods)
+	}
+
+	candidates := slices.Clone(options.Candidates)
+	if len(candidates) == 0 {
+		candidates = SystemConstantRange(GLICKO2_LOW_SYSTEM_CONSTANT, GLICKO2_HIGH_SYSTEM_CONSTANT, 10)
+	}
+	slices.Sort(candidates)
+
+	variants := make([]Glicko2AlgorithmSettings, len(candidates))
+	for idx, systemConstant := range candidates {
+		variants[idx] = options.Settings
+		variants[idx].SystemConstant = systemConstant
+		if err := variants[idx].Validate(); err != nil {
+			return SystemConstantTuning{}, fmt.Errorf("candidate %v: %w", systemConstant, err)
+		}
+	}
+
+	totalLogLoss := make([]float64, len(candidates))
+	heldOutMatches := 0
+	firstHeldOut := len(periods) - options.HeldOutPeriods
+
+	_, err := ReplayPeriodSeqFunc(players, slices.Values(periods), func(period ReplayedPeriod[ID]) error {
+		if period.Index < firstHeldOut {
+			return nil
+		}
+		if period.Variant == 0 {
+			heldOutMatches += len(period.Matches)
+		}
+		for _, match := range period.Matches {
+			totalLogLoss[period.Variant] += matchLogLoss(period.Before, match)
+		}
+		return nil
+	}, variants...)
+	if err != nil {
+		return SystemConstantTuning{}, err
+	}
+
+	if heldOutMatches == 0 {
+		return SystemConstantTuning{}, errors.New("held out periods contain no matches to score")
+	}
+
+	tuning := SystemConstantTuning{
+		Scores:         make([]SystemConstantScore, len(candidates)),
+		HeldOutMatches: heldOutMatches,
+	}
+	bestIdx := 0
+	for idx, systemConstant := range candidates {
+		tuning.Scores[idx] = SystemConstantScore{SystemConstant: systemConstant, LogLoss: totalLogLoss[idx] / float64(heldOutMatches)}
+		if tuning.Scores[idx].LogLoss < tuning.Scores[bestIdx].LogLoss {
+			bestIdx = idx
+		}
+	}
+	tuning.Best = variants[bestIdx]
+
+	return tuning, nil
+}
+
+// matchLogLoss returns the PredictionLogLoss of predicting `match` from `players`. Both players are assumed to exist,
+// as an invalid match fails the replay and discards the score.
+func matchLogLoss[ID comparable](players map[ID]Glicko2Player, match Glicko2Match[ID]) float64 {
+	return PredictionLogLoss(players[match.Player1ID].WinProbability(players[match.Player2ID]), match.Result)
+}
@@ -0,0 +1,124 @@
+package glicko2go
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// simulateArchive plays random matches between 50 players over `periodCount` periods,
+// where each outcome is drawn from the players' hidden true ratings.
+func simulateArchive(random *rand.Rand, periodCount int) (map[int]Glicko2Player, [][]Glicko2MatchByID) {
+	trueRatings := make([]float64, 50)
+	players := make(map[int]Glicko2Player, len(trueRatings))
+	for id := range trueRatings {
+		trueRatings[id] = random.NormFloat64()
+		players[id] = NewDefaultGlicko2Player()
+	}
+
+	periods := make([][]Glicko2MatchByID, periodCount)
+	for periodIdx := range periods {
+		for range 200 {
+			player1ID, player2ID := random.Intn(len(trueRatings)), random.Intn(len(trueRatings)-1)
+			if player2ID >= player1ID {
+				player2ID++
+			}
+			result := GAME_OUTCOME_LOSS
+			if random.Float64() < 1/(1+math.Exp(trueRatings[player2ID]-trueRatings[player1ID])) {
+				result = GAME_OUTCOME_WIN
+			}
+			periods[periodIdx] = append(periods[periodIdx], Glicko2MatchByID{Player1ID: player1ID, Player2ID: player2ID, Result: result})
+		}
+	}
+	return players, periods
+}
+
+func TestTuneSystemConstant(t *testing.T) {
+	players, periods := simulateArchive(rand.New(rand.NewSource(1)), 6)
+	candidates := []float64{1.2, 0.3, 0.6}
+
+	tuning, err := TuneSystemConstant(players, periods, SystemConstantTuningOptions{
+		Settings:       glicko2DefaultSettings,
+		Candidates:     candidates,
+		HeldOutPeriods: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tuning.HeldOutMatches != len(periods[4])+len(periods[5]) {
+		t.Errorf("Expected %v held out matches, got %v", len(periods[4])+len(periods[5]), tuning.HeldOutMatches)
+	}
+	if len(tuning.Scores) != len(candidates) {
+		t.Fatalf("Expected %v scores, got %v", len(candidates), len(tuning.Scores))
+	}
+
+	best := tuning.Scores[0]
+	for idx, score := range tuning.Scores {
+		if idx > 0 && score.SystemConstant <= tuning.Scores[idx-1].SystemConstant {
+			t.Errorf("Scores are not ordered by system constant: %+v", tuning.Scores)
+		}
+		if !(score.LogLoss > 0) || score.LogLoss > math.Ln2*2 {
+			t.Errorf("Log-loss of %v is implausible", score)
+		}
+		if score.LogLoss < best.LogLoss {
+			best = score
+		}
+	}
+	if tuning.Best.SystemConstant != best.SystemConstant || tuning.Best.ConvergenceTolerance != glicko2DefaultSettings.ConvergenceTolerance {
+		t.Errorf("Expected the best settings to use a system constant of %v, got %+v", best.SystemConstant, tuning.Best)
+	}
+}
+
+// TestTuneSystemConstantScoresPrePeriodPlayers checks a single candidate's log-loss against the players replayed before the held-out period.
+func TestTuneSystemConstantScoresPrePeriodPlayers(t *testing.T) {
+	players, periods := simulateArchive(rand.New(rand.NewSource(2)), 3)
+
+	tuning, err := TuneSystemConstant(players, periods, SystemConstantTuningOptions{
+		Settings:       glicko2DefaultSettings,
+		Candidates:     []float64{GLICKO2_DEFAULT_SYSTEM_CONSTANT},
+		HeldOutPeriods: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replays, err := ReplayPeriods(players, periods, glicko2DefaultSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prePeriodPlayers := replays[0].PlayersBefore(2)
+
+	expectedLogLoss := 0.0
+	for _, match := range periods[2] {
+		probability := prePeriodPlayers[match.Player1ID].WinProbability(prePeriodPlayers[match.Player2ID])
+		if match.Result == GAME_OUTCOME_WIN {
+			expectedLogLoss -= math.Log(probability)
+		} else {
+			expectedLogLoss -= math.Log(1 - probability)
+		}
+	}
+	expectedLogLoss /= float64(len(periods[2]))
+
+	if math.Abs(tuning.Scores[0].LogLoss-expectedLogLoss) > 1e-12 {
+		t.Errorf("Expected a log-loss of %v, got %v", expectedLogLoss, tuning.Scores[0].LogLoss)
+	}
+}
+
+func TestTuneSystemConstantErrors(t *testing.T) {
+	players, periods := simulateArchive(rand.New(rand.NewSource(3)), 2)
+
+	for name, options := range map[string]SystemConstantTuningOptions{
+		"no held out periods":       {Settings: glicko2DefaultSettings},
+		"too many held out periods": {Settings: glicko2DefaultSettings, HeldOutPeriods: 3},
+		"invalid candidate":         {Settings: glicko2DefaultSettings, HeldOutPeriods: 1, Candidates: []float64{-1}},
+	} {
+		if _, err := TuneSystemConstant(players, periods, options); err == nil {
+			t.Errorf("Tuning with %v did not cause an error", name)
+		}
+	}
+
+	if _, err := TuneSystemConstant(players, [][]Glicko2MatchByID{periods[0], nil}, SystemConstantTuningOptions{Settings: glicko2DefaultSettings, HeldOutPeriods: 1}); err == nil {
+		t.Errorf("Tuning without any held out matches did not cause an error")
+	}
+}
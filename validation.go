@@ -0,0 +1,123 @@
+package glicko2go
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MatchValidationPolicy determines how a period calculator handles matches that fail validation.
+type MatchValidationPolicy int
+
+const (
+	// MATCH_VALIDATION_STRICT fails the whole period if any match is invalid.
+	MATCH_VALIDATION_STRICT MatchValidationPolicy = iota
+	// MATCH_VALIDATION_LENIENT skips invalid matches, reporting them alongside the period's results.
+	MATCH_VALIDATION_LENIENT
+)
+
+// MatchIssueKind describes why a match failed validation.
+type MatchIssueKind int
+
+const (
+	// MATCH_ISSUE_UNKNOWN_PLAYER is used when a match references a player ID that is not in the players map.
+	MATCH_ISSUE_UNKNOWN_PLAYER MatchIssueKind = iota
+	// MATCH_ISSUE_SELF_PLAY is used when both sides of a match are the same player.
+	MATCH_ISSUE_SELF_PLAY
+	// MATCH_ISSUE_RESULT_OUT_OF_RANGE is used when a match result is not within [GAME_OUTCOME_LOSS, GAME_OUTCOME_WIN].
+	MATCH_ISSUE_RESULT_OUT_OF_RANGE
+	// MATCH_ISSUE_EMPTY_TEAM is used when a side of a team match has no players.
+	MATCH_ISSUE_EMPTY_TEAM
+)
+
+func (k MatchIssueKind) String() string {
+	switch k {
+	case MATCH_ISSUE_UNKNOWN_PLAYER:
+		return "unknown player"
+	case MATCH_ISSUE_SELF_PLAY:
+		return "self-play"
+	case MATCH_ISSUE_RESULT_OUT_OF_RANGE:
+		return "result out of range"
+	case MATCH_ISSUE_EMPTY_TEAM:
+		return "empty team"
+	default:
+		return fmt.Sprintf("MatchIssueKind(%d)", int(k))
+	}
+}
+
+// MatchIssue is a single problem found with a match. A match may have several issues.
+type MatchIssue[ID comparable] struct {
+	// MatchIndex is the index of the offending match within the slice passed to the period calculator.
+	MatchIndex int
+	Match      Glicko2Match[ID]
+	Kind       MatchIssueKind
+	// PlayerID is the unknown ID when Kind is MATCH_ISSUE_UNKNOWN_PLAYER.
+	PlayerID ID
+}
+
+func (i MatchIssue[ID]) String() string {
+	switch i.Kind {
+	case MATCH_ISSUE_UNKNOWN_PLAYER:
+		return fmt.Sprintf("match %v: unknown player ID %v", i.MatchIndex, i.PlayerID)
+	case MATCH_ISSUE_SELF_PLAY:
+		return fmt.Sprintf("match %v: player %v cannot play themselves", i.MatchIndex, i.Match.Player1ID)
+	case MATCH_ISSUE_RESULT_OUT_OF_RANGE:
+		return fmt.Sprintf("match %v: result %v is outside of [%v, %v]", i.MatchIndex, i.Match.Result, GAME_OUTCOME_LOSS, GAME_OUTCOME_WIN)
+	default:
+		return fmt.Sprintf("match %v: %v", i.MatchIndex, i.Kind)
+	}
+}
+
+// MatchValidationError is returned by period calculators when one or more matches are invalid.
+// Every issue found is listed, rather than only the first.
+type MatchValidationError[ID comparable] struct {
+	Issues []MatchIssue[ID]
+}
+
+func (e *MatchValidationError[ID]) Error() string {
+	descriptions := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		descriptions[i] = issue.String()
+	}
+	return fmt.Sprintf("%v invalid match issue(s): %v", len(e.Issues), strings.Join(descriptions, "; "))
+}
+
+// ValidateMatches checks every match against `players`, returning all issues found.
+// `players` may hold players of either the Glicko or Glicko 2 system.
+// A nil slice is returned if every match is valid.
+func ValidateMatches[ID comparable, P any](players map[ID]P, matches []Glicko2Match[ID]) []MatchIssue[ID] {
+	var issues []MatchIssue[ID]
+
+	for idx, match := range matches {
+		if _, ok := players[match.Player1ID]; !ok {
+			issues = append(issues, MatchIssue[ID]{MatchIndex: idx, Match: match, Kind: MATCH_ISSUE_UNKNOWN_PLAYER, PlayerID: match.Player1ID})
+		}
+		if _, ok := players[match.Player2ID]; !ok && match.Player2ID != match.Player1ID {
+			issues = append(issues, MatchIssue[ID]{MatchIndex: idx, Match: match, Kind: MATCH_ISSUE_UNKNOWN_PLAYER, PlayerID: match.Player2ID})
+		}
+		if match.Player1ID == match.Player2ID {
+			issues = append(issues, MatchIssue[ID]{MatchIndex: idx, Match: match, Kind: MATCH_ISSUE_SELF_PLAY})
+		}
+		if math.IsNaN(match.Result) || match.Result < GAME_OUTCOME_LOSS || match.Result > GAME_OUTCOME_WIN {
+			issues = append(issues, MatchIssue[ID]{MatchIndex: idx, Match: match, Kind: MATCH_ISSUE_RESULT_OUT_OF_RANGE})
+		}
+	}
+
+	return issues
+}
+
+// filterInvalidMatches returns `matches` without any match referenced by `issues`.
+func filterInvalidMatches[ID comparable](matches []Glicko2Match[ID], issues []MatchIssue[ID]) []Glicko2Match[ID] {
+	invalid := make(map[int]bool, len(issues))
+	for _, issue := range issues {
+		invalid[issue.MatchIndex] = true
+	}
+
+	validMatches := make([]Glicko2Match[ID], 0, len(matches)-len(invalid))
+	for idx, match := range matches {
+		if !invalid[idx] {
+			validMatches = append(validMatches, match)
+		}
+	}
+	return validMatches
+}